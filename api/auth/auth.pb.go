@@ -623,6 +623,53 @@ func (x *FindUserByIdRequest) GetId() string {
 	return ""
 }
 
+type FindUserByPhoneRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Phone string `protobuf:"bytes,1,opt,name=phone,proto3" json:"phone,omitempty"`
+}
+
+func (x *FindUserByPhoneRequest) Reset() {
+	*x = FindUserByPhoneRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_auth_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindUserByPhoneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindUserByPhoneRequest) ProtoMessage() {}
+
+func (x *FindUserByPhoneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindUserByPhoneRequest.ProtoReflect.Descriptor instead.
+func (*FindUserByPhoneRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FindUserByPhoneRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
 type FindUsersByIdsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -634,7 +681,7 @@ type FindUsersByIdsRequest struct {
 func (x *FindUsersByIdsRequest) Reset() {
 	*x = FindUsersByIdsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_auth_proto_msgTypes[10]
+		mi := &file_auth_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -647,7 +694,7 @@ func (x *FindUsersByIdsRequest) String() string {
 func (*FindUsersByIdsRequest) ProtoMessage() {}
 
 func (x *FindUsersByIdsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[10]
+	mi := &file_auth_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -660,7 +707,7 @@ func (x *FindUsersByIdsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FindUsersByIdsRequest.ProtoReflect.Descriptor instead.
 func (*FindUsersByIdsRequest) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{10}
+	return file_auth_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *FindUsersByIdsRequest) GetIds() []string {
@@ -681,7 +728,7 @@ type FindUsersByIdsResponse struct {
 func (x *FindUsersByIdsResponse) Reset() {
 	*x = FindUsersByIdsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_auth_proto_msgTypes[11]
+		mi := &file_auth_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -694,7 +741,7 @@ func (x *FindUsersByIdsResponse) String() string {
 func (*FindUsersByIdsResponse) ProtoMessage() {}
 
 func (x *FindUsersByIdsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[11]
+	mi := &file_auth_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -707,7 +754,7 @@ func (x *FindUsersByIdsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FindUsersByIdsResponse.ProtoReflect.Descriptor instead.
 func (*FindUsersByIdsResponse) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{11}
+	return file_auth_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *FindUsersByIdsResponse) GetUsers() []*User {
@@ -766,7 +813,10 @@ var file_auth_proto_rawDesc = []byte{
 	0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65,
 	0x4e, 0x61, 0x6d, 0x65, 0x22, 0x25, 0x0a, 0x13, 0x46, 0x69, 0x6e, 0x64, 0x55, 0x73, 0x65, 0x72,
 	0x42, 0x79, 0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x29, 0x0a, 0x15, 0x46,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x2e, 0x0a, 0x16, 0x46,
+	0x69, 0x6e, 0x64, 0x55, 0x73, 0x65, 0x72, 0x42, 0x79, 0x50, 0x68, 0x6f, 0x6e, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x22, 0x29, 0x0a, 0x15, 0x46,
 	0x69, 0x6e, 0x64, 0x55, 0x73, 0x65, 0x72, 0x73, 0x42, 0x79, 0x49, 0x64, 0x73, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
 	0x09, 0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0x3a, 0x0a, 0x16, 0x46, 0x69, 0x6e, 0x64, 0x55, 0x73,
@@ -775,7 +825,7 @@ var file_auth_proto_rawDesc = []byte{
 	0x0a, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x05, 0x75, 0x73, 0x65,
 	0x72, 0x73, 0x2a, 0x1b, 0x0a, 0x04, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x61, 0x64,
 	0x6d, 0x69, 0x6e, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x10, 0x01, 0x32,
-	0xf0, 0x02, 0x0a, 0x04, 0x41, 0x75, 0x74, 0x68, 0x12, 0x2b, 0x0a, 0x06, 0x53, 0x69, 0x67, 0x6e,
+	0xad, 0x03, 0x0a, 0x04, 0x41, 0x75, 0x74, 0x68, 0x12, 0x2b, 0x0a, 0x06, 0x53, 0x69, 0x67, 0x6e,
 	0x49, 0x6e, 0x12, 0x13, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x54,
 	0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x2b, 0x0a, 0x06, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x70, 0x12,
@@ -798,8 +848,12 @@ var file_auth_proto_rawDesc = []byte{
 	0x69, 0x6e, 0x64, 0x55, 0x73, 0x65, 0x72, 0x73, 0x42, 0x79, 0x49, 0x64, 0x73, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x46, 0x69, 0x6e, 0x64,
 	0x55, 0x73, 0x65, 0x72, 0x73, 0x42, 0x79, 0x49, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x42, 0x0a, 0x5a, 0x08, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x75, 0x74, 0x68, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x65, 0x12, 0x3b, 0x0a, 0x0f, 0x46, 0x69, 0x6e, 0x64, 0x55, 0x73, 0x65, 0x72, 0x42, 0x79,
+	0x50, 0x68, 0x6f, 0x6e, 0x65, 0x12, 0x1c, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x46, 0x69, 0x6e,
+	0x64, 0x55, 0x73, 0x65, 0x72, 0x42, 0x79, 0x50, 0x68, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x42,
+	0x0a, 0x5a, 0x08, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x75, 0x74, 0x68, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -815,7 +869,7 @@ func file_auth_proto_rawDescGZIP() []byte {
 }
 
 var file_auth_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_auth_proto_goTypes = []interface{}{
 	(Role)(0),                      // 0: auth.Role
 	(*Empty)(nil),                  // 1: auth.Empty
@@ -828,8 +882,9 @@ var file_auth_proto_goTypes = []interface{}{
 	(*Tokens)(nil),                 // 8: auth.Tokens
 	(*User)(nil),                   // 9: auth.User
 	(*FindUserByIdRequest)(nil),    // 10: auth.FindUserByIdRequest
-	(*FindUsersByIdsRequest)(nil),  // 11: auth.FindUsersByIdsRequest
-	(*FindUsersByIdsResponse)(nil), // 12: auth.FindUsersByIdsResponse
+	(*FindUserByPhoneRequest)(nil), // 11: auth.FindUserByPhoneRequest
+	(*FindUsersByIdsRequest)(nil),  // 12: auth.FindUsersByIdsRequest
+	(*FindUsersByIdsResponse)(nil), // 13: auth.FindUsersByIdsResponse
 }
 var file_auth_proto_depIdxs = []int32{
 	0,  // 0: auth.AuthRequest.role:type_name -> auth.Role
@@ -841,16 +896,18 @@ var file_auth_proto_depIdxs = []int32{
 	5,  // 6: auth.Auth.Auth:input_type -> auth.AuthRequest
 	7,  // 7: auth.Auth.Refresh:input_type -> auth.RefreshRequest
 	10, // 8: auth.Auth.FindUserById:input_type -> auth.FindUserByIdRequest
-	11, // 9: auth.Auth.FindUsersByIds:input_type -> auth.FindUsersByIdsRequest
-	8,  // 10: auth.Auth.SignIn:output_type -> auth.Tokens
-	8,  // 11: auth.Auth.SignUp:output_type -> auth.Tokens
-	1,  // 12: auth.Auth.SignOut:output_type -> auth.Empty
-	6,  // 13: auth.Auth.Auth:output_type -> auth.AuthResponse
-	8,  // 14: auth.Auth.Refresh:output_type -> auth.Tokens
-	9,  // 15: auth.Auth.FindUserById:output_type -> auth.User
-	12, // 16: auth.Auth.FindUsersByIds:output_type -> auth.FindUsersByIdsResponse
-	10, // [10:17] is the sub-list for method output_type
-	3,  // [3:10] is the sub-list for method input_type
+	12, // 9: auth.Auth.FindUsersByIds:input_type -> auth.FindUsersByIdsRequest
+	11, // 10: auth.Auth.FindUserByPhone:input_type -> auth.FindUserByPhoneRequest
+	8,  // 11: auth.Auth.SignIn:output_type -> auth.Tokens
+	8,  // 12: auth.Auth.SignUp:output_type -> auth.Tokens
+	1,  // 13: auth.Auth.SignOut:output_type -> auth.Empty
+	6,  // 14: auth.Auth.Auth:output_type -> auth.AuthResponse
+	8,  // 15: auth.Auth.Refresh:output_type -> auth.Tokens
+	9,  // 16: auth.Auth.FindUserById:output_type -> auth.User
+	13, // 17: auth.Auth.FindUsersByIds:output_type -> auth.FindUsersByIdsResponse
+	9,  // 18: auth.Auth.FindUserByPhone:output_type -> auth.User
+	11, // [11:19] is the sub-list for method output_type
+	3,  // [3:11] is the sub-list for method input_type
 	3,  // [3:3] is the sub-list for extension type_name
 	3,  // [3:3] is the sub-list for extension extendee
 	0,  // [0:3] is the sub-list for field type_name
@@ -983,7 +1040,7 @@ func file_auth_proto_init() {
 			}
 		}
 		file_auth_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FindUsersByIdsRequest); i {
+			switch v := v.(*FindUserByPhoneRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -995,6 +1052,18 @@ func file_auth_proto_init() {
 			}
 		}
 		file_auth_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindUsersByIdsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_auth_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*FindUsersByIdsResponse); i {
 			case 0:
 				return &v.state
@@ -1013,7 +1082,7 @@ func file_auth_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_auth_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   12,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -29,6 +29,7 @@ type AuthClient interface {
 	Refresh(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (*Tokens, error)
 	FindUserById(ctx context.Context, in *FindUserByIdRequest, opts ...grpc.CallOption) (*User, error)
 	FindUsersByIds(ctx context.Context, in *FindUsersByIdsRequest, opts ...grpc.CallOption) (*FindUsersByIdsResponse, error)
+	FindUserByPhone(ctx context.Context, in *FindUserByPhoneRequest, opts ...grpc.CallOption) (*User, error)
 }
 
 type authClient struct {
@@ -102,6 +103,15 @@ func (c *authClient) FindUsersByIds(ctx context.Context, in *FindUsersByIdsReque
 	return out, nil
 }
 
+func (c *authClient) FindUserByPhone(ctx context.Context, in *FindUserByPhoneRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/auth.Auth/FindUserByPhone", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthServer is the server API for Auth service.
 // All implementations must embed UnimplementedAuthServer
 // for forward compatibility
@@ -113,6 +123,7 @@ type AuthServer interface {
 	Refresh(context.Context, *RefreshRequest) (*Tokens, error)
 	FindUserById(context.Context, *FindUserByIdRequest) (*User, error)
 	FindUsersByIds(context.Context, *FindUsersByIdsRequest) (*FindUsersByIdsResponse, error)
+	FindUserByPhone(context.Context, *FindUserByPhoneRequest) (*User, error)
 	mustEmbedUnimplementedAuthServer()
 }
 
@@ -141,6 +152,9 @@ func (UnimplementedAuthServer) FindUserById(context.Context, *FindUserByIdReques
 func (UnimplementedAuthServer) FindUsersByIds(context.Context, *FindUsersByIdsRequest) (*FindUsersByIdsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindUsersByIds not implemented")
 }
+func (UnimplementedAuthServer) FindUserByPhone(context.Context, *FindUserByPhoneRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindUserByPhone not implemented")
+}
 func (UnimplementedAuthServer) mustEmbedUnimplementedAuthServer() {}
 
 // UnsafeAuthServer may be embedded to opt out of forward compatibility for this service.
@@ -280,6 +294,24 @@ func _Auth_FindUsersByIds_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Auth_FindUserByPhone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindUserByPhoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).FindUserByPhone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.Auth/FindUserByPhone",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).FindUserByPhone(ctx, req.(*FindUserByPhoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Auth_ServiceDesc is the grpc.ServiceDesc for Auth service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -315,6 +347,10 @@ var Auth_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FindUsersByIds",
 			Handler:    _Auth_FindUsersByIds_Handler,
 		},
+		{
+			MethodName: "FindUserByPhone",
+			Handler:    _Auth_FindUserByPhone_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "auth.proto",
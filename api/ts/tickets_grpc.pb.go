@@ -26,6 +26,10 @@ type TicketServiceClient interface {
 	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
 	FindById(ctx context.Context, in *FindByIdRequest, opts ...grpc.CallOption) (*Ticket, error)
 	CloseTicket(ctx context.Context, in *CloseTicketRequest, opts ...grpc.CallOption) (*Empty, error)
+	Approve(ctx context.Context, in *ApproveRequest, opts ...grpc.CallOption) (*Empty, error)
+	Reject(ctx context.Context, in *RejectRequest, opts ...grpc.CallOption) (*Empty, error)
+	UpdateItem(ctx context.Context, in *UpdateItemRequest, opts ...grpc.CallOption) (*Ticket, error)
+	SaveAnalysis(ctx context.Context, in *SaveAnalysisRequest, opts ...grpc.CallOption) (*Empty, error)
 	GetUserSummary(ctx context.Context, in *Empty, opts ...grpc.CallOption) (TicketService_GetUserSummaryClient, error)
 	GetStatusSummary(ctx context.Context, in *Empty, opts ...grpc.CallOption) (TicketService_GetStatusSummaryClient, error)
 	GetShopSummary(ctx context.Context, in *Empty, opts ...grpc.CallOption) (TicketService_GetShopSummaryClient, error)
@@ -75,6 +79,42 @@ func (c *ticketServiceClient) CloseTicket(ctx context.Context, in *CloseTicketRe
 	return out, nil
 }
 
+func (c *ticketServiceClient) Approve(ctx context.Context, in *ApproveRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/ticket.TicketService/Approve", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) Reject(ctx context.Context, in *RejectRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/ticket.TicketService/Reject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) UpdateItem(ctx context.Context, in *UpdateItemRequest, opts ...grpc.CallOption) (*Ticket, error) {
+	out := new(Ticket)
+	err := c.cc.Invoke(ctx, "/ticket.TicketService/UpdateItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) SaveAnalysis(ctx context.Context, in *SaveAnalysisRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/ticket.TicketService/SaveAnalysis", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *ticketServiceClient) GetUserSummary(ctx context.Context, in *Empty, opts ...grpc.CallOption) (TicketService_GetUserSummaryClient, error) {
 	stream, err := c.cc.NewStream(ctx, &TicketService_ServiceDesc.Streams[0], "/ticket.TicketService/GetUserSummary", opts...)
 	if err != nil {
@@ -179,6 +219,10 @@ type TicketServiceServer interface {
 	List(context.Context, *ListRequest) (*ListResponse, error)
 	FindById(context.Context, *FindByIdRequest) (*Ticket, error)
 	CloseTicket(context.Context, *CloseTicketRequest) (*Empty, error)
+	Approve(context.Context, *ApproveRequest) (*Empty, error)
+	Reject(context.Context, *RejectRequest) (*Empty, error)
+	UpdateItem(context.Context, *UpdateItemRequest) (*Ticket, error)
+	SaveAnalysis(context.Context, *SaveAnalysisRequest) (*Empty, error)
 	GetUserSummary(*Empty, TicketService_GetUserSummaryServer) error
 	GetStatusSummary(*Empty, TicketService_GetStatusSummaryServer) error
 	GetShopSummary(*Empty, TicketService_GetShopSummaryServer) error
@@ -201,6 +245,18 @@ func (UnimplementedTicketServiceServer) FindById(context.Context, *FindByIdReque
 func (UnimplementedTicketServiceServer) CloseTicket(context.Context, *CloseTicketRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CloseTicket not implemented")
 }
+func (UnimplementedTicketServiceServer) Approve(context.Context, *ApproveRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Approve not implemented")
+}
+func (UnimplementedTicketServiceServer) Reject(context.Context, *RejectRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reject not implemented")
+}
+func (UnimplementedTicketServiceServer) UpdateItem(context.Context, *UpdateItemRequest) (*Ticket, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateItem not implemented")
+}
+func (UnimplementedTicketServiceServer) SaveAnalysis(context.Context, *SaveAnalysisRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveAnalysis not implemented")
+}
 func (UnimplementedTicketServiceServer) GetUserSummary(*Empty, TicketService_GetUserSummaryServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetUserSummary not implemented")
 }
@@ -295,6 +351,78 @@ func _TicketService_CloseTicket_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TicketService_Approve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).Approve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ticket.TicketService/Approve",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).Approve(ctx, req.(*ApproveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_Reject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).Reject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ticket.TicketService/Reject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).Reject(ctx, req.(*RejectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_UpdateItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).UpdateItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ticket.TicketService/UpdateItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).UpdateItem(ctx, req.(*UpdateItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_SaveAnalysis_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveAnalysisRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).SaveAnalysis(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ticket.TicketService/SaveAnalysis",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).SaveAnalysis(ctx, req.(*SaveAnalysisRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TicketService_GetUserSummary_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(Empty)
 	if err := stream.RecvMsg(m); err != nil {
@@ -381,6 +509,22 @@ var TicketService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CloseTicket",
 			Handler:    _TicketService_CloseTicket_Handler,
 		},
+		{
+			MethodName: "Approve",
+			Handler:    _TicketService_Approve_Handler,
+		},
+		{
+			MethodName: "Reject",
+			Handler:    _TicketService_Reject_Handler,
+		},
+		{
+			MethodName: "UpdateItem",
+			Handler:    _TicketService_UpdateItem_Handler,
+		},
+		{
+			MethodName: "SaveAnalysis",
+			Handler:    _TicketService_SaveAnalysis_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1,27 +1,50 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"os/signal"
+	"syscall"
 
 	"github.com/mzhn-sochi/gateway/internal/app"
 )
 
 func main() {
 
-	a := app.InitApp()
+	a, err := app.InitApp()
+	if err != nil {
+		log.Fatalf("failed to init app: %s", err.Error())
+	}
 
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	done := make(chan error, 1)
 	go func() {
-		if err := a.Run(); err != nil {
-			log.Printf("app crashed: %s\n", err.Error())
-		}
+		done <- a.Run()
 	}()
 
-	sig := <-sigChan
+	select {
+	case err := <-done:
+		log.Printf("app crashed: %s\n", err.Error())
+		os.Exit(1)
+	case <-ctx.Done():
+	}
+
+	// a second signal terminates the process without waiting for the drain
+	stop()
+
+	log.Printf("caught signal, graceful shutdown")
+	if err := a.Shutdown(); err != nil {
+		log.Printf("shutdown failed: %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	if err := <-done; err != nil {
+		log.Printf("app crashed: %s\n", err.Error())
+		os.Exit(1)
+	}
 
-	log.Printf("caught sig: %+v. graceful shutdown", sig)
-	a.Shutdown()
+	log.Printf("stopped")
 }
@@ -0,0 +1,259 @@
+package analysis
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/mzhn-sochi/gateway/internal/apierror"
+	"github.com/mzhn-sochi/gateway/internal/config"
+	"github.com/mzhn-sochi/gateway/internal/entity"
+	"github.com/mzhn-sochi/gateway/pkg/cache"
+	"github.com/mzhn-sochi/gateway/pkg/file"
+	"github.com/mzhn-sochi/gateway/pkg/middleware"
+)
+
+// ErrQueueFull is answered with 503, the client may retry once the workers
+// caught up.
+var ErrQueueFull error = queueFullError{}
+
+var ErrClosed = errors.New("analysis pipeline is closed")
+
+const (
+	queueRetryAfter = 5 * time.Second
+	saveTimeout     = 10 * time.Second
+)
+
+type queueFullError struct{}
+
+func (queueFullError) Error() string {
+	return "analysis queue is full"
+}
+
+func (queueFullError) RetryAfter() time.Duration {
+	return queueRetryAfter
+}
+
+type Analyzer interface {
+	Analyze(ctx context.Context, reader file.Reader) (*entity.ImageInfo, error)
+}
+
+// Store keeps finished analyses with their tickets, the in-memory results
+// only cover recent ones.
+type Store interface {
+	SaveAnalysis(ctx context.Context, ticketId string, a *entity.Analysis) error
+}
+
+type job struct {
+	ticketId    string
+	requestId   string
+	image       []byte
+	contentType string
+}
+
+// Pipeline recognizes price tags of created tickets in the background. A
+// bounded queue feeds a fixed number of workers, and the progress of every
+// ticket is kept for a while so clients can poll it. Finished analyses are
+// saved with the ticket.
+//
+// A place in the queue is reserved before the ticket is created, so a ticket
+// is never created when its image cannot be analyzed.
+type Pipeline struct {
+	config   *config.Config
+	logger   *slog.Logger
+	analyzer Analyzer
+	store    Store
+	errors   *apierror.Mapping
+	results  *cache.Cache[string, entity.Analysis]
+
+	mu     sync.RWMutex
+	closed bool
+	queue  chan job
+	// slots holds a token for every reserved place in the queue until a
+	// worker takes the job
+	slots chan struct{}
+	wg    sync.WaitGroup
+}
+
+func New(config *config.Config, logger *slog.Logger, analyzer Analyzer, store Store, mapping *apierror.Mapping) *Pipeline {
+	c := config.Analysis
+
+	p := &Pipeline{
+		config:   config,
+		logger:   logger.With("component", "analysis"),
+		analyzer: analyzer,
+		store:    store,
+		errors:   mapping,
+		results:  cache.New[string, entity.Analysis](c.Results, c.ResultTTL),
+		queue:    make(chan job, c.Queue),
+		slots:    make(chan struct{}, c.Queue),
+	}
+
+	if !c.Async {
+		return p
+	}
+
+	for i := 0; i < c.Workers; i++ {
+		p.wg.Add(1)
+		go p.work()
+	}
+
+	p.logger.Info("analysis pipeline started", slog.Int("workers", c.Workers), slog.Int("queue", c.Queue))
+
+	return p
+}
+
+func (p *Pipeline) Enabled() bool {
+	return p.config.Analysis.Async
+}
+
+// Reserve takes a place in the queue for the next Submit. It fails with
+// ErrQueueFull when there is none left.
+func (p *Pipeline) Reserve() error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return ErrClosed
+	}
+
+	select {
+	case p.slots <- struct{}{}:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Release gives back a place taken by Reserve that Submit did not use.
+func (p *Pipeline) Release() {
+	select {
+	case <-p.slots:
+	default:
+	}
+}
+
+// Submit queues the image of a ticket for analysis into the place taken by
+// Reserve. The returned progress is failed when the pipeline is closed.
+func (p *Pipeline) Submit(ctx context.Context, ticketId string, image []byte, contentType string) *entity.Analysis {
+	rid, _ := ctx.Value(middleware.REQUEST_ID).(string)
+
+	j := job{
+		ticketId:    ticketId,
+		requestId:   rid,
+		image:       image,
+		contentType: contentType,
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		p.Release()
+		return p.update(ticketId, entity.AnalysisFailed, nil, ErrClosed)
+	}
+
+	// the progress is stored before queueing so a fast worker cannot be overwritten
+	a := p.update(ticketId, entity.AnalysisQueued, nil, nil)
+
+	// never blocks, every queued job holds one of the reserved places
+	p.queue <- j
+
+	return a
+}
+
+func (p *Pipeline) Get(ticketId string) (*entity.Analysis, bool) {
+	a, ok := p.results.Get(ticketId)
+	if !ok {
+		return nil, false
+	}
+	return &a, true
+}
+
+// Close stops accepting images and waits for queued ones until ctx is done.
+func (p *Pipeline) Close(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.queue)
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *Pipeline) work() {
+	defer p.wg.Done()
+
+	for j := range p.queue {
+		<-p.slots
+		p.analyze(j)
+	}
+}
+
+func (p *Pipeline) analyze(j job) {
+	l := p.logger.With(slog.String("ticketId", j.ticketId), slog.String("requestId", j.requestId))
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.config.Analysis.Timeout)
+	defer cancel()
+
+	// services expect the values the HTTP middleware puts on a request
+	ctx = context.WithValue(ctx, middleware.LOGGER, l)
+	ctx = context.WithValue(ctx, middleware.REQUEST_ID, j.requestId)
+
+	p.update(j.ticketId, entity.AnalysisRunning, nil, nil)
+
+	start := time.Now()
+	info, err := p.analyzer.Analyze(ctx, file.NewReader(bytes.NewReader(j.image), int64(len(j.image)), j.contentType))
+	if err != nil {
+		l.Warn("failed to analyze ticket image", slog.String("err", err.Error()))
+		p.save(ctx, p.update(j.ticketId, entity.AnalysisFailed, nil, err))
+		return
+	}
+
+	l.Debug("ticket image analyzed", slog.Duration("duration", time.Since(start)))
+	p.save(ctx, p.update(j.ticketId, entity.AnalysisDone, info, nil))
+}
+
+// save stores a finished analysis with the ticket. A failure only costs the
+// result once it leaves the in-memory results.
+func (p *Pipeline) save(ctx context.Context, a *entity.Analysis) {
+	// the analysis may have used up the deadline
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), saveTimeout)
+	defer cancel()
+
+	if err := p.store.SaveAnalysis(ctx, a.TicketId, a); err != nil {
+		ctx.Value(middleware.LOGGER).(*slog.Logger).Error("failed to save ticket analysis", slog.String("err", err.Error()))
+	}
+}
+
+func (p *Pipeline) update(ticketId, status string, result *entity.ImageInfo, err error) *entity.Analysis {
+	a := entity.Analysis{
+		TicketId:  ticketId,
+		Status:    status,
+		Result:    result,
+		UpdatedAt: time.Now().Unix(),
+	}
+	if err != nil {
+		// upstream failures are reported the same way as in error responses
+		a.Error = p.errors.From(err).Message
+	}
+
+	p.results.Set(ticketId, a)
+
+	return &a
+}
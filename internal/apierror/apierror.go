@@ -0,0 +1,241 @@
+package apierror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const StatusClientClosedRequest = 499
+
+const (
+	CodeBadRequest       = "bad_request"
+	CodeValidation       = "validation_failed"
+	CodeUnauthenticated  = "unauthenticated"
+	CodePermissionDenied = "permission_denied"
+	CodeNotFound         = "not_found"
+	CodeConflict         = "conflict"
+	CodeTooManyRequests  = "too_many_requests"
+	CodeCanceled         = "canceled"
+	CodeInternal         = "internal"
+	CodeNotImplemented   = "not_implemented"
+	CodeUnavailable      = "unavailable"
+	CodeDeadlineExceeded = "deadline_exceeded"
+)
+
+// Error is the body of every failed response.
+type Error struct {
+	Status    int          `json:"-"`
+	Code      string       `json:"code"`
+	Message   string       `json:"message"`
+	RequestId string       `json:"requestId,omitempty"`
+	Details   any          `json:"details,omitempty"`
+	Fields    []FieldError `json:"fields,omitempty"`
+
+	// RetryAfter is sent as the Retry-After header when set.
+	RetryAfter time.Duration `json:"-"`
+
+	cause error
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+func New(status int, code, message string) *Error {
+	return &Error{
+		Status:  status,
+		Code:    code,
+		Message: message,
+	}
+}
+
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return fmt.Sprintf("%s: %s", e.Message, e.cause.Error())
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// retryAfter is implemented by errors of calls rejected before reaching an
+// upstream, such as an open circuit breaker.
+type retryAfter interface {
+	error
+	RetryAfter() time.Duration
+}
+
+// Sentinel maps a sentinel error of a service to the response it produces.
+type Sentinel struct {
+	Err    error
+	Status int
+	Code   string
+}
+
+// Mapping translates errors into responses. It is built once with the
+// sentinels of every service and shared by everything that reports errors.
+type Mapping struct {
+	sentinels []Sentinel
+}
+
+func NewMapping(sentinels ...Sentinel) *Mapping {
+	return &Mapping{
+		sentinels: sentinels,
+	}
+}
+
+// From translates any error returned by a handler into an Error. Messages of
+// server side failures are replaced so that upstream details do not leak.
+func (m *Mapping) From(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+
+	var fe *fiber.Error
+	if errors.As(err, &fe) {
+		return New(fe.Code, codeForStatus(fe.Code), fe.Message)
+	}
+
+	var ve validator.ValidationErrors
+	if errors.As(err, &ve) {
+		e = New(fiber.StatusBadRequest, CodeValidation, "request validation failed")
+		for _, f := range ve {
+			e.Fields = append(e.Fields, FieldError{
+				Field:   f.Field(),
+				Rule:    f.Tag(),
+				Param:   f.Param(),
+				Message: f.Error(),
+			})
+		}
+		return e
+	}
+
+	var ra retryAfter
+	if errors.As(err, &ra) {
+		return &Error{
+			Status:     fiber.StatusServiceUnavailable,
+			Code:       CodeUnavailable,
+			Message:    ra.Error(),
+			RetryAfter: ra.RetryAfter(),
+			cause:      err,
+		}
+	}
+
+	for _, s := range m.sentinels {
+		if errors.Is(err, s.Err) {
+			return &Error{Status: s.Status, Code: s.Code, Message: err.Error(), cause: err}
+		}
+	}
+
+	if st, ok := status.FromError(err); ok && st.Code() != codes.Unknown {
+		return fromStatus(st, err)
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return &Error{Status: fiber.StatusGatewayTimeout, Code: CodeDeadlineExceeded, Message: "upstream timed out", cause: err}
+	case errors.Is(err, context.Canceled):
+		return &Error{Status: StatusClientClosedRequest, Code: CodeCanceled, Message: "request canceled", cause: err}
+	}
+
+	return &Error{Status: fiber.StatusInternalServerError, Code: CodeInternal, Message: "internal error", cause: err}
+}
+
+// StatusCode is the HTTP status the error handler answers with for err.
+func (m *Mapping) StatusCode(err error) int {
+	return m.From(err).Status
+}
+
+func fromStatus(st *status.Status, err error) *Error {
+	e := &Error{
+		Message: st.Message(),
+		Details: fiber.Map{"upstreamCode": st.Code().String()},
+		cause:   err,
+	}
+
+	switch st.Code() {
+	case codes.InvalidArgument, codes.OutOfRange, codes.FailedPrecondition:
+		e.Status, e.Code = fiber.StatusBadRequest, CodeBadRequest
+	case codes.Unauthenticated:
+		e.Status, e.Code = fiber.StatusUnauthorized, CodeUnauthenticated
+	case codes.PermissionDenied:
+		e.Status, e.Code = fiber.StatusForbidden, CodePermissionDenied
+	case codes.NotFound:
+		e.Status, e.Code = fiber.StatusNotFound, CodeNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		e.Status, e.Code = fiber.StatusConflict, CodeConflict
+	case codes.ResourceExhausted:
+		e.Status, e.Code = fiber.StatusTooManyRequests, CodeTooManyRequests
+	case codes.Canceled:
+		e.Status, e.Code, e.Message = StatusClientClosedRequest, CodeCanceled, "request canceled"
+	case codes.DeadlineExceeded:
+		e.Status, e.Code, e.Message = fiber.StatusGatewayTimeout, CodeDeadlineExceeded, "upstream timed out"
+	case codes.Unimplemented:
+		e.Status, e.Code, e.Message = fiber.StatusNotImplemented, CodeNotImplemented, "not implemented"
+	case codes.Unavailable:
+		e.Status, e.Code, e.Message = fiber.StatusServiceUnavailable, CodeUnavailable, "upstream service unavailable"
+	default:
+		e.Status, e.Code, e.Message = fiber.StatusInternalServerError, CodeInternal, "internal error"
+	}
+
+	return e
+}
+
+func codeForStatus(code int) string {
+	switch code {
+	case fiber.StatusBadRequest, fiber.StatusUnprocessableEntity:
+		return CodeBadRequest
+	case fiber.StatusUnauthorized:
+		return CodeUnauthenticated
+	case fiber.StatusForbidden:
+		return CodePermissionDenied
+	case fiber.StatusNotFound:
+		return CodeNotFound
+	case fiber.StatusConflict:
+		return CodeConflict
+	case fiber.StatusTooManyRequests:
+		return CodeTooManyRequests
+	case fiber.StatusNotImplemented:
+		return CodeNotImplemented
+	case fiber.StatusServiceUnavailable:
+		return CodeUnavailable
+	case fiber.StatusGatewayTimeout:
+		return CodeDeadlineExceeded
+	}
+
+	if code >= fiber.StatusInternalServerError {
+		return CodeInternal
+	}
+
+	return snake(http.StatusText(code))
+}
+
+func snake(s string) string {
+	b := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'A' && c <= 'Z':
+			b = append(b, c+'a'-'A')
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+			b = append(b, c)
+		case len(b) > 0 && b[len(b)-1] != '_':
+			b = append(b, '_')
+		}
+	}
+	return string(b)
+}
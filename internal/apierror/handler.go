@@ -0,0 +1,39 @@
+package apierror
+
+import (
+	"log/slog"
+	"math"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mzhn-sochi/gateway/pkg/middleware"
+)
+
+// Handler renders errors returned by route handlers as an Error envelope.
+func Handler(logger *slog.Logger, mapping *Mapping) fiber.ErrorHandler {
+	return func(ctx *fiber.Ctx, err error) error {
+		e := mapping.From(err)
+
+		l, ok := ctx.Locals(middleware.LOGGER).(*slog.Logger)
+		if !ok {
+			l = logger
+		}
+
+		if e.Status >= fiber.StatusInternalServerError {
+			l.Error("request failed", slog.Int("status", e.Status), slog.String("err", err.Error()))
+		} else {
+			l.Debug("request rejected", slog.Int("status", e.Status), slog.String("err", err.Error()))
+		}
+
+		body := *e
+		if rid, ok := ctx.Locals(middleware.REQUEST_ID).(string); ok {
+			body.RequestId = rid
+		}
+
+		if e.RetryAfter > 0 {
+			ctx.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(e.RetryAfter.Seconds()))))
+		}
+
+		return ctx.Status(e.Status).JSON(&body)
+	}
+}
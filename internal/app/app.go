@@ -1,16 +1,25 @@
 package app
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
-	"github.com/mzhn-sochi/gateway/api/auth"
+	"github.com/mzhn-sochi/gateway/internal/analysis"
+	"github.com/mzhn-sochi/gateway/internal/apierror"
 	"github.com/mzhn-sochi/gateway/internal/config"
 	"github.com/mzhn-sochi/gateway/internal/controllers"
+	"github.com/mzhn-sochi/gateway/internal/events"
+	"github.com/mzhn-sochi/gateway/internal/grpcconn"
+	"github.com/mzhn-sochi/gateway/internal/health"
+	"github.com/mzhn-sochi/gateway/internal/idempotency"
+	"github.com/mzhn-sochi/gateway/internal/metrics"
+	"github.com/mzhn-sochi/gateway/internal/ratelimit"
+	"github.com/mzhn-sochi/gateway/internal/tracing"
 	"github.com/mzhn-sochi/gateway/pkg/middleware"
 	"log/slog"
+	"time"
 )
 
 type App struct {
@@ -21,6 +30,17 @@ type App struct {
 	suggestionsController *controllers.SuggestionsController
 	AuthController        *controllers.AuthController
 	ticketController      *controllers.TicketController
+	priceTagController    *controllers.PriceTagController
+	healthController      *controllers.HealthController
+	metrics               *metrics.Metrics
+	tracing               *tracing.Tracing
+	conns                 *grpcconn.Manager
+	health                *health.Checker
+	limiter               *ratelimit.Limiter
+	idempotency           *idempotency.Keeper
+	analysis              *analysis.Pipeline
+	events                *events.Poller
+	errors                *apierror.Mapping
 }
 
 func newApp(
@@ -29,25 +49,27 @@ func newApp(
 	suggestionsController *controllers.SuggestionsController,
 	authController *controllers.AuthController,
 	ticketController *controllers.TicketController,
+	priceTagController *controllers.PriceTagController,
+	healthController *controllers.HealthController,
+	metrics *metrics.Metrics,
+	tracing *tracing.Tracing,
+	conns *grpcconn.Manager,
+	health *health.Checker,
+	limiter *ratelimit.Limiter,
+	idempotency *idempotency.Keeper,
+	analysis *analysis.Pipeline,
+	events *events.Poller,
+	mapping *apierror.Mapping,
 ) *App {
 	app := fiber.New(fiber.Config{
 		AppName:       "sochya-gateway",
 		CaseSensitive: true,
-		ErrorHandler: func(ctx *fiber.Ctx, err error) error {
-			code := fiber.StatusInternalServerError
+		ErrorHandler:  apierror.Handler(log, mapping),
+		BodyLimit:     10 << 20,
+		ProxyHeader:   config.App.ProxyHeader,
 
-			var e *fiber.Error
-			if errors.As(err, &e) {
-				code = e.Code
-			}
-
-			err = ctx.Status(code).JSON(fiber.Map{
-				"message": e.Message,
-			})
-
-			return nil
-		},
-		BodyLimit: 10 << 20,
+		EnableTrustedProxyCheck: config.App.ProxyHeader != "",
+		TrustedProxies:          config.App.TrustedProxies,
 	})
 
 	return &App{
@@ -57,6 +79,17 @@ func newApp(
 		suggestionsController: suggestionsController,
 		AuthController:        authController,
 		ticketController:      ticketController,
+		priceTagController:    priceTagController,
+		healthController:      healthController,
+		metrics:               metrics,
+		tracing:               tracing,
+		conns:                 conns,
+		health:                health,
+		limiter:               limiter,
+		idempotency:           idempotency,
+		analysis:              analysis,
+		events:                events,
+		errors:                mapping,
 	}
 }
 
@@ -65,44 +98,58 @@ func (a *App) Run() error {
 	host := a.config.App.Host
 	port := a.config.App.Port
 
+	a.app.Use(a.metrics.HTTP(a.errors))
 	a.app.Use(logger.New())
 	a.app.Use(middleware.AttachRequestId())
+	a.app.Use(a.tracing.Middleware(a.errors))
 	a.app.Use(middleware.AttachLogger(a.logger))
 
-	a.app.Use(cors.New(cors.Config{
-		AllowOrigins:     "https://localhost:5173, http://localhost:5173",
-		AllowHeaders:     "Origin, Content-Type, Accept, Authorization",
-		AllowCredentials: true,
-	}))
+	a.app.Use(newCORS(a.config.CORS))
+
+	a.app.Get("/healthz", a.healthController.Liveness())
+	a.app.Get("/readyz", a.healthController.Readiness())
+	a.app.Get("/metrics", a.metrics.Handler())
 
 	v1 := a.app.Group("/api/v1")
+	for _, r := range a.routes() {
+		v1.Add(r.method, r.path, a.limiter.IP(), a.AuthController.Enforce(r.policy), r.limit, a.idempotency.Handler(), r.handler)
+	}
 
-	au := v1.Group("/auth")
-	au.Post("/sign-in", a.AuthController.SignIn())
-	au.Post("/sign-up", a.AuthController.SignUp())
-	au.Post("/sign-out", a.AuthController.AuthRequired(auth.Role_user), a.AuthController.SignOut())
-	au.Post("/refresh", a.AuthController.Refresh())
+	a.logger.Info("server started", slog.String("host", host), slog.Int("port", port))
+	return a.app.Listen(fmt.Sprintf("%s:%d", host, port))
+}
 
-	v1.Get("/suggestions", a.suggestionsController.GetSuggestions())
+// Shutdown turns readiness off, waits for load balancers to notice, drains
+// in-flight requests and then releases upstream connections and exporters.
+func (a *App) Shutdown() error {
+	a.health.Drain()
 
-	tt := v1.Group("/tickets")
-	tt.Get("/", a.ticketController.List())
-	tt.Get("/:id", a.ticketController.Find())
-	tt.Post("/", a.AuthController.AuthRequired(auth.Role_user), a.ticketController.Create())
-	tt.Patch("/:id", a.ticketController.CloseTicket())
+	a.logger.Info("draining", slog.Duration("delay", a.config.Shutdown.Delay), slog.Duration("timeout", a.config.Shutdown.Timeout))
+	time.Sleep(a.config.Shutdown.Delay)
 
-	v1.Get("/user/tickets", a.AuthController.AuthRequired(auth.Role_user), a.ticketController.ListUsers())
-	v1.Get("/profile", a.AuthController.AuthRequired(auth.Role_user), a.AuthController.Profile())
+	// event streams never finish on their own and would hold the drain
+	a.events.Close()
 
-	summary := v1.Group("/summary")
-	summary.Get("/users", a.ticketController.UserSummary())
-	summary.Get("/statuses", a.ticketController.StatusSummary())
-	summary.Get("/shops", a.ticketController.ShopSummary())
+	var errs []error
 
-	a.logger.Info("server started", slog.String("host", host), slog.Int("port", port))
-	return a.app.Listen(fmt.Sprintf("%s:%d", host, port))
-}
+	if err := a.app.ShutdownWithTimeout(a.config.Shutdown.Timeout); err != nil {
+		errs = append(errs, fmt.Errorf("drain http server: %w", err))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.config.Shutdown.Timeout)
+	defer cancel()
+
+	if err := a.analysis.Close(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("finish ticket analysis: %w", err))
+	}
+
+	if err := a.conns.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("close upstream connections: %w", err))
+	}
+
+	if err := a.tracing.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("flush traces: %w", err))
+	}
 
-func (a *App) Shutdown() {
-	a.app.Shutdown()
+	return errors.Join(errs...)
 }
@@ -0,0 +1,28 @@
+package app
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/mzhn-sochi/gateway/internal/config"
+	"github.com/mzhn-sochi/gateway/pkg/middleware"
+)
+
+func newCORS(config config.CORS) fiber.Handler {
+	expose := config.ExposeHeaders
+	// browsers need the request id to report errors to support
+	if !slices.ContainsFunc(expose, func(h string) bool { return strings.EqualFold(h, middleware.RequestIdHeader) }) {
+		expose = append(slices.Clip(expose), middleware.RequestIdHeader)
+	}
+
+	return cors.New(cors.Config{
+		AllowOrigins:     strings.Join(config.AllowOrigins, ","),
+		AllowMethods:     strings.Join(config.AllowMethods, ","),
+		AllowHeaders:     strings.Join(config.AllowHeaders, ","),
+		ExposeHeaders:    strings.Join(expose, ","),
+		AllowCredentials: config.AllowCredentials,
+		MaxAge:           int(config.MaxAge.Seconds()),
+	})
+}
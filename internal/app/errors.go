@@ -0,0 +1,31 @@
+package app
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/mzhn-sochi/gateway/internal/analysis"
+	"github.com/mzhn-sochi/gateway/internal/apierror"
+	"github.com/mzhn-sochi/gateway/internal/service/analyzerservice"
+	"github.com/mzhn-sochi/gateway/internal/service/authservice"
+	"github.com/mzhn-sochi/gateway/internal/service/s3service"
+	"github.com/mzhn-sochi/gateway/internal/service/ticketservice"
+)
+
+// newErrorMapping lists the responses produced by the sentinel errors of the
+// services.
+func newErrorMapping() *apierror.Mapping {
+	return apierror.NewMapping(
+		apierror.Sentinel{Err: authservice.ErrUnauthorized, Status: fiber.StatusUnauthorized, Code: apierror.CodeUnauthenticated},
+		apierror.Sentinel{Err: authservice.ErrForbidden, Status: fiber.StatusForbidden, Code: apierror.CodePermissionDenied},
+		apierror.Sentinel{Err: authservice.ErrNotFound, Status: fiber.StatusNotFound, Code: apierror.CodeNotFound},
+		apierror.Sentinel{Err: authservice.ErrInvalidRequest, Status: fiber.StatusBadRequest, Code: apierror.CodeBadRequest},
+
+		apierror.Sentinel{Err: ticketservice.ErrTicketNotFound, Status: fiber.StatusNotFound, Code: apierror.CodeNotFound},
+		apierror.Sentinel{Err: ticketservice.ErrInvalidStatus, Status: fiber.StatusBadRequest, Code: apierror.CodeBadRequest},
+		apierror.Sentinel{Err: ticketservice.ErrInvalidTransition, Status: fiber.StatusConflict, Code: apierror.CodeConflict},
+
+		apierror.Sentinel{Err: s3service.ErrInvalidImage, Status: fiber.StatusBadRequest, Code: apierror.CodeBadRequest},
+		apierror.Sentinel{Err: analyzerservice.ErrInvalidImage, Status: fiber.StatusBadRequest, Code: apierror.CodeBadRequest},
+
+		apierror.Sentinel{Err: analysis.ErrClosed, Status: fiber.StatusServiceUnavailable, Code: apierror.CodeUnavailable},
+	)
+}
@@ -0,0 +1,33 @@
+package app
+
+import (
+	"github.com/mzhn-sochi/gateway/internal/config"
+	"github.com/mzhn-sochi/gateway/internal/health"
+	"github.com/mzhn-sochi/gateway/internal/resilience"
+	"github.com/mzhn-sochi/gateway/internal/service/analyzerservice"
+	"github.com/mzhn-sochi/gateway/internal/service/authservice"
+	"github.com/mzhn-sochi/gateway/internal/service/s3service"
+	"github.com/mzhn-sochi/gateway/internal/service/suggestions"
+	"github.com/mzhn-sochi/gateway/internal/service/ticketservice"
+)
+
+func newHealthChecker(
+	config *config.Config,
+	resilience *resilience.Resilience,
+	authService *authservice.Service,
+	ticketService *ticketservice.Service,
+	s3Service *s3service.S3Service,
+	suggestionsService *suggestions.Service,
+	analyzerService *analyzerservice.Service,
+) *health.Checker {
+	return health.NewChecker(
+		config.Health.Timeout,
+		config.Health.Optional,
+		resilience,
+		authService,
+		ticketService,
+		s3Service,
+		suggestionsService,
+		analyzerService,
+	)
+}
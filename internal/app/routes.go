@@ -0,0 +1,59 @@
+package app
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/mzhn-sochi/gateway/api/auth"
+	"github.com/mzhn-sochi/gateway/internal/controllers"
+)
+
+type route struct {
+	method  string
+	path    string
+	policy  controllers.Policy
+	limit   fiber.Handler
+	handler fiber.Handler
+}
+
+func (a *App) routes() []route {
+	var (
+		public = controllers.Public()
+		user   = controllers.RequireRole(auth.Role_user)
+		admin  = controllers.RequireRole(auth.Role_admin)
+
+		credentials = a.limiter.Auth()
+		upload      = a.limiter.Upload()
+		standard    = a.limiter.Default()
+	)
+
+	return []route{
+		{fiber.MethodPost, "/auth/sign-in", public, credentials, a.AuthController.SignIn()},
+		{fiber.MethodPost, "/auth/sign-up", public, credentials, a.AuthController.SignUp()},
+		{fiber.MethodPost, "/auth/sign-out", user, standard, a.AuthController.SignOut()},
+		{fiber.MethodPost, "/auth/refresh", public, credentials, a.AuthController.Refresh()},
+
+		{fiber.MethodGet, "/suggestions", public, standard, a.suggestionsController.GetSuggestions()},
+
+		{fiber.MethodGet, "/tickets", admin, standard, a.ticketController.List()},
+		{fiber.MethodGet, "/tickets/:id", controllers.OwnerOrAdmin(a.ticketController.Owner()), standard, a.ticketController.Find()},
+		{fiber.MethodGet, "/tickets/:id/analysis", controllers.OwnerOrAdmin(a.ticketController.Owner()), standard, a.ticketController.Analysis()},
+		{fiber.MethodPost, "/tickets", user, upload, a.ticketController.Create()},
+		{fiber.MethodPatch, "/tickets/:id", admin, standard, a.ticketController.CloseTicket()},
+		{fiber.MethodPost, "/tickets/:id/approve", admin, standard, a.ticketController.Approve()},
+		{fiber.MethodPost, "/tickets/:id/reject", admin, standard, a.ticketController.Reject()},
+		{fiber.MethodPatch, "/tickets/:id/item", admin, standard, a.ticketController.UpdateItem()},
+
+		{fiber.MethodPost, "/pricetags/analyze", user, upload, a.priceTagController.Analyze()},
+
+		{fiber.MethodGet, "/user/tickets", user, standard, a.ticketController.ListUsers()},
+		{fiber.MethodGet, "/user/tickets/events", user, standard, a.ticketController.Events()},
+		{fiber.MethodGet, "/profile", user, standard, a.AuthController.Profile()},
+
+		{fiber.MethodGet, "/admin/cache/users", admin, standard, a.AuthController.UserCacheStats()},
+		{fiber.MethodDelete, "/admin/cache/users", admin, standard, a.AuthController.InvalidateUserCache()},
+		{fiber.MethodDelete, "/admin/cache/users/:id", admin, standard, a.AuthController.InvalidateUserCache()},
+
+		{fiber.MethodGet, "/summary/users", admin, standard, a.ticketController.UserSummary()},
+		{fiber.MethodGet, "/summary/statuses", admin, standard, a.ticketController.StatusSummary()},
+		{fiber.MethodGet, "/summary/shops", admin, standard, a.ticketController.ShopSummary()},
+	}
+}
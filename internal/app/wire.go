@@ -4,30 +4,58 @@
 package app
 
 import (
+	"github.com/mzhn-sochi/gateway/internal/analysis"
 	"github.com/mzhn-sochi/gateway/internal/config"
+	"github.com/mzhn-sochi/gateway/internal/events"
+	"github.com/mzhn-sochi/gateway/internal/grpcconn"
+	"github.com/mzhn-sochi/gateway/internal/health"
+	"github.com/mzhn-sochi/gateway/internal/idempotency"
+	"github.com/mzhn-sochi/gateway/internal/imagecheck"
+	"github.com/mzhn-sochi/gateway/internal/lockout"
 	"github.com/mzhn-sochi/gateway/internal/logger"
+	"github.com/mzhn-sochi/gateway/internal/metrics"
+	"github.com/mzhn-sochi/gateway/internal/ratelimit"
+	"github.com/mzhn-sochi/gateway/internal/resilience"
+	"github.com/mzhn-sochi/gateway/internal/service/analyzerservice"
 	"github.com/mzhn-sochi/gateway/internal/service/authservice"
 	"github.com/mzhn-sochi/gateway/internal/service/s3service"
 	"github.com/mzhn-sochi/gateway/internal/service/suggestions"
 	"github.com/mzhn-sochi/gateway/internal/service/ticketservice"
+	"github.com/mzhn-sochi/gateway/internal/tracing"
 
 	"github.com/google/wire"
 	"github.com/mzhn-sochi/gateway/internal/controllers"
 )
 
-func InitApp() *App {
+func InitApp() (*App, error) {
 	panic(wire.Build(
 		newApp,
+		wire.NewSet(newErrorMapping),
 		wire.NewSet(logger.New),
 		wire.NewSet(config.New),
+		wire.NewSet(metrics.New),
+		wire.NewSet(tracing.New),
+		wire.NewSet(resilience.New),
+		wire.NewSet(grpcconn.New),
+
+		wire.NewSet(ratelimit.NewMemoryStore),
+		wire.Bind(new(ratelimit.Store), new(*ratelimit.MemoryStore)),
+		wire.NewSet(ratelimit.New),
+
+		wire.NewSet(idempotency.NewMemoryStore),
+		wire.Bind(new(idempotency.Store), new(*idempotency.MemoryStore)),
+		wire.NewSet(idempotency.New),
 
 		wire.NewSet(suggestions.New),
+		wire.Bind(new(controllers.SuggestionsService), new(*suggestions.Service)),
 		wire.NewSet(controllers.NewSuggestionsController),
 
 		wire.NewSet(authservice.New),
 		wire.Bind(new(controllers.AuthService), new(*authservice.Service)),
 		wire.Bind(new(controllers.UserFinder), new(*authservice.Service)),
 		wire.Bind(new(controllers.UserService), new(*authservice.Service)),
+		wire.NewSet(lockout.New),
+		wire.Bind(new(controllers.LoginGuard), new(*lockout.Guard)),
 		wire.NewSet(controllers.NewAuthController),
 
 		wire.NewSet(ticketservice.New),
@@ -35,6 +63,23 @@ func InitApp() *App {
 		wire.Bind(new(controllers.TicketsService), new(*ticketservice.Service)),
 		wire.Bind(new(controllers.FileUploader), new(*s3service.S3Service)),
 		wire.Bind(new(controllers.SummaryService), new(*ticketservice.Service)),
+		wire.Bind(new(events.Lister), new(*ticketservice.Service)),
+		wire.NewSet(events.NewPoller),
+		wire.Bind(new(controllers.TicketEvents), new(*events.Poller)),
+		wire.NewSet(imagecheck.New),
+		wire.Bind(new(controllers.ImageValidator), new(*imagecheck.Validator)),
 		wire.NewSet(controllers.NewTicketController),
+
+		wire.NewSet(analyzerservice.New),
+		wire.Bind(new(controllers.PriceTagAnalyzer), new(*analyzerservice.Service)),
+		wire.Bind(new(analysis.Analyzer), new(*analyzerservice.Service)),
+		wire.Bind(new(analysis.Store), new(*ticketservice.Service)),
+		wire.NewSet(analysis.New),
+		wire.Bind(new(controllers.TicketAnalysis), new(*analysis.Pipeline)),
+		wire.NewSet(controllers.NewPriceTagController),
+
+		wire.NewSet(newHealthChecker),
+		wire.Bind(new(controllers.HealthChecker), new(*health.Checker)),
+		wire.NewSet(controllers.NewHealthController),
 	))
 }
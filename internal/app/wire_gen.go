@@ -7,27 +7,76 @@
 package app
 
 import (
+	"github.com/mzhn-sochi/gateway/internal/analysis"
 	"github.com/mzhn-sochi/gateway/internal/config"
 	"github.com/mzhn-sochi/gateway/internal/controllers"
+	"github.com/mzhn-sochi/gateway/internal/events"
+	"github.com/mzhn-sochi/gateway/internal/grpcconn"
+	"github.com/mzhn-sochi/gateway/internal/idempotency"
+	"github.com/mzhn-sochi/gateway/internal/imagecheck"
+	"github.com/mzhn-sochi/gateway/internal/lockout"
 	"github.com/mzhn-sochi/gateway/internal/logger"
+	"github.com/mzhn-sochi/gateway/internal/metrics"
+	"github.com/mzhn-sochi/gateway/internal/ratelimit"
+	"github.com/mzhn-sochi/gateway/internal/resilience"
+	"github.com/mzhn-sochi/gateway/internal/service/analyzerservice"
 	"github.com/mzhn-sochi/gateway/internal/service/authservice"
 	"github.com/mzhn-sochi/gateway/internal/service/s3service"
 	"github.com/mzhn-sochi/gateway/internal/service/suggestions"
 	"github.com/mzhn-sochi/gateway/internal/service/ticketservice"
+	"github.com/mzhn-sochi/gateway/internal/tracing"
 )
 
 // Injectors from wire.go:
 
-func InitApp() *App {
+func InitApp() (*App, error) {
 	configConfig := config.New()
 	slogLogger := logger.New(configConfig)
-	suggestionsService := suggestions.New(configConfig, slogLogger)
-	suggestionsController := controllers.NewSuggestionsController(suggestionsService)
-	service := authservice.New(configConfig, slogLogger)
-	authController := controllers.NewAuthController(service, service)
-	ticketserviceService := ticketservice.New(configConfig, slogLogger)
-	s3Service := s3service.New(configConfig, slogLogger)
-	ticketController := controllers.NewTicketController(ticketserviceService, s3Service, service, ticketserviceService)
-	app := newApp(configConfig, slogLogger, suggestionsController, authController, ticketController)
-	return app
+	metricsMetrics := metrics.New()
+	tracingTracing := tracing.New(configConfig, slogLogger)
+	resilienceResilience := resilience.New()
+	manager, err := grpcconn.New(configConfig, slogLogger, metricsMetrics, tracingTracing, resilienceResilience)
+	if err != nil {
+		return nil, err
+	}
+	service, err := suggestions.New(configConfig, slogLogger, manager)
+	if err != nil {
+		return nil, err
+	}
+	suggestionsController := controllers.NewSuggestionsController(service)
+	authserviceService, err := authservice.New(configConfig, slogLogger, metricsMetrics, manager)
+	if err != nil {
+		return nil, err
+	}
+	guard := lockout.New(configConfig, slogLogger)
+	mapping := newErrorMapping()
+	authController := controllers.NewAuthController(authserviceService, authserviceService, guard, mapping)
+	ticketserviceService, err := ticketservice.New(configConfig, manager)
+	if err != nil {
+		return nil, err
+	}
+	s3Service, err := s3service.New(configConfig, metricsMetrics, manager)
+	if err != nil {
+		return nil, err
+	}
+	analyzerserviceService, err := analyzerservice.New(configConfig, manager)
+	if err != nil {
+		return nil, err
+	}
+	pipeline := analysis.New(configConfig, slogLogger, analyzerserviceService, ticketserviceService, mapping)
+	poller := events.NewPoller(configConfig, slogLogger, ticketserviceService)
+	validator := imagecheck.New(configConfig)
+	ticketController := controllers.NewTicketController(ticketserviceService, s3Service, authserviceService, ticketserviceService, pipeline, poller, validator, mapping, configConfig)
+	priceTagController := controllers.NewPriceTagController(analyzerserviceService)
+	checker := newHealthChecker(configConfig, resilienceResilience, authserviceService, ticketserviceService, s3Service, service, analyzerserviceService)
+	healthController := controllers.NewHealthController(checker)
+	memoryStore := ratelimit.NewMemoryStore()
+	limiter, err := ratelimit.New(configConfig, slogLogger, memoryStore)
+	if err != nil {
+		return nil, err
+	}
+	idempotencyMemoryStore := idempotency.NewMemoryStore(configConfig)
+	keeper := idempotency.New(configConfig, slogLogger, idempotencyMemoryStore)
+	app := newApp(configConfig, slogLogger, suggestionsController, authController, ticketController, priceTagController, healthController, metricsMetrics, tracingTracing, manager, checker, limiter, keeper, pipeline, poller, mapping)
+	return app, nil
 }
@@ -2,45 +2,234 @@ package config
 
 import (
 	"os"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/mzhn-sochi/gateway/pkg/certs"
 )
 
 type Config struct {
 	App struct {
 		Host string `env:"APP_HOST" env-default:"0.0.0.0"`
 		Port int    `env:"APP_PORT" env-default:"8080"`
+		// ProxyHeader names the header carrying the client address when the
+		// gateway runs behind a load balancer, e.g. X-Real-IP. It is only
+		// honoured on connections from TrustedProxies.
+		ProxyHeader    string   `env:"APP_PROXY_HEADER"`
+		TrustedProxies []string `env:"APP_TRUSTED_PROXIES" env-separator:","`
 	}
+	Shutdown struct {
+		// Delay keeps serving after readiness turns unready, giving load
+		// balancers time to stop routing before connections are drained.
+		Delay   time.Duration `env:"SHUTDOWN_DELAY" env-default:"5s"`
+		Timeout time.Duration `env:"SHUTDOWN_TIMEOUT" env-default:"20s"`
+	}
+
+	CORS CORS
+
 	Services struct {
 		PriceTagAnalyzer struct {
-			Host string `env:"PRICE_TAG_ANALYZER_HOST" env-default:"77.221.158.75"`
-			Port int    `env:"PRICE_TAG_ANALYZER_PORT" env-default:"50051"`
+			Host       string     `env:"PRICE_TAG_ANALYZER_HOST" env-default:"77.221.158.75"`
+			Port       int        `env:"PRICE_TAG_ANALYZER_PORT" env-default:"50051"`
+			Resilience Resilience `env-prefix:"PRICE_TAG_ANALYZER_"`
+			TLS        TLS        `env-prefix:"PRICE_TAG_ANALYZER_"`
 		}
 
 		Suggestions struct {
-			Host string `env:"SUGGESTIONS_HOST" env-default:"localhost"`
-			Port int    `env:"SUGGESTIONS_PORT" env-default:"2020"`
+			Host       string     `env:"SUGGESTIONS_HOST" env-default:"localhost"`
+			Port       int        `env:"SUGGESTIONS_PORT" env-default:"2020"`
+			Resilience Resilience `env-prefix:"SUGGESTIONS_"`
+			TLS        TLS        `env-prefix:"SUGGESTIONS_"`
 		}
 
 		TicketService struct {
-			Host string `env:"TICKET_SERVICE_HOST" env-default:"localhost"`
-			Port int    `env:"TICKET_SERVICE_PORT" env-default:"50052"`
+			Host       string     `env:"TICKET_SERVICE_HOST" env-default:"localhost"`
+			Port       int        `env:"TICKET_SERVICE_PORT" env-default:"50052"`
+			Resilience Resilience `env-prefix:"TICKET_SERVICE_"`
+			TLS        TLS        `env-prefix:"TICKET_SERVICE_"`
 		}
 
 		AuthService struct {
-			Host string `env:"AUTH_SERVICE_HOST" env-default:"localhost"`
-			Port int    `env:"AUTH_SERVICE_PORT" env-default:"50053"`
+			Host       string     `env:"AUTH_SERVICE_HOST" env-default:"localhost"`
+			Port       int        `env:"AUTH_SERVICE_PORT" env-default:"50053"`
+			Resilience Resilience `env-prefix:"AUTH_SERVICE_"`
+			TLS        TLS        `env-prefix:"AUTH_SERVICE_"`
 		}
 
 		S3Service struct {
-			Host string `env:"S3_SERVICE_HOST" env-default:"localhost"`
-			Port int    `env:"S3_SERVICE_PORT" env-default:"50054"`
+			Host       string     `env:"S3_SERVICE_HOST" env-default:"localhost"`
+			Port       int        `env:"S3_SERVICE_PORT" env-default:"50054"`
+			Resilience Resilience `env-prefix:"S3_SERVICE_"`
+			TLS        TLS        `env-prefix:"S3_SERVICE_"`
 		}
 	}
 
+	GRPC struct {
+		// LoadBalancing is "round_robin" (across every address the upstream
+		// host resolves to) or "pick_first".
+		LoadBalancing    string        `env:"GRPC_LOAD_BALANCING" env-default:"round_robin"`
+		KeepaliveTime    time.Duration `env:"GRPC_KEEPALIVE_TIME" env-default:"5m"`
+		KeepaliveTimeout time.Duration `env:"GRPC_KEEPALIVE_TIMEOUT" env-default:"20s"`
+	}
+
+	Auth struct {
+		// Mode is either "remote" (every token is checked by the auth service)
+		// or "local" (tokens are verified by the gateway itself).
+		Mode string `env:"AUTH_MODE" env-default:"remote"`
+
+		JWT struct {
+			Secret        string        `env:"AUTH_JWT_SECRET"`
+			PublicKeyFile string        `env:"AUTH_JWT_PUBLIC_KEY_FILE"`
+			JWKS          string        `env:"AUTH_JWT_JWKS"`
+			JWKSRefresh   time.Duration `env:"AUTH_JWT_JWKS_REFRESH" env-default:"10m"`
+			Issuer        string        `env:"AUTH_JWT_ISSUER"`
+			UserIdClaim   string        `env:"AUTH_JWT_USER_ID_CLAIM" env-default:"sub"`
+			RoleClaim     string        `env:"AUTH_JWT_ROLE_CLAIM" env-default:"role"`
+		}
+
+		RevocationCheckInterval time.Duration `env:"AUTH_REVOCATION_CHECK_INTERVAL" env-default:"1m"`
+		RevocationCacheSize     int           `env:"AUTH_REVOCATION_CACHE_SIZE" env-default:"10000"`
+	}
+
+	Health struct {
+		Timeout  time.Duration `env:"HEALTH_CHECK_TIMEOUT" env-default:"2s"`
+		Optional []string      `env:"HEALTH_OPTIONAL_UPSTREAMS" env-separator:","`
+	}
+
+	Tracing struct {
+		// Exporter is one of "none", "stdout" or "otlp".
+		Exporter    string  `env:"TRACING_EXPORTER" env-default:"none"`
+		Endpoint    string  `env:"TRACING_OTLP_ENDPOINT" env-default:"localhost:4317"`
+		Insecure    bool    `env:"TRACING_OTLP_INSECURE" env-default:"true"`
+		SampleRatio float64 `env:"TRACING_SAMPLE_RATIO" env-default:"1"`
+		ServiceName string  `env:"TRACING_SERVICE_NAME" env-default:"sochya-gateway"`
+	}
+
+	RateLimit struct {
+		Enabled bool `env:"RATE_LIMIT_ENABLED" env-default:"true"`
+		// Limits are written as requests/period, e.g. 10/1m.
+		IP      string `env:"RATE_LIMIT_IP" env-default:"600/1m"`
+		Auth    string `env:"RATE_LIMIT_AUTH" env-default:"10/1m"`
+		Upload  string `env:"RATE_LIMIT_UPLOAD" env-default:"20/1m"`
+		Default string `env:"RATE_LIMIT_DEFAULT" env-default:"300/1m"`
+	}
+
+	// Lockout throttles failed sign-ins per phone number and per client
+	// address. Past the allowed attempts every failure locks the key for
+	// Base, 2*Base, 4*Base... up to Max.
+	Lockout struct {
+		PhoneAttempts int           `env:"LOGIN_LOCKOUT_PHONE_ATTEMPTS" env-default:"5"`
+		IPAttempts    int           `env:"LOGIN_LOCKOUT_IP_ATTEMPTS" env-default:"20"`
+		Base          time.Duration `env:"LOGIN_LOCKOUT_BASE" env-default:"30s"`
+		Max           time.Duration `env:"LOGIN_LOCKOUT_MAX" env-default:"1h"`
+		Window        time.Duration `env:"LOGIN_LOCKOUT_WINDOW" env-default:"15m"`
+	}
+
+	Analysis struct {
+		// Async answers ticket creation with 202 and recognizes the price
+		// tag in the background, results are kept for ResultTTL.
+		Async     bool          `env:"ANALYSIS_ASYNC" env-default:"false"`
+		Workers   int           `env:"ANALYSIS_WORKERS" env-default:"4"`
+		Queue     int           `env:"ANALYSIS_QUEUE_SIZE" env-default:"16"`
+		Timeout   time.Duration `env:"ANALYSIS_TIMEOUT" env-default:"1m"`
+		Results   int           `env:"ANALYSIS_RESULTS_SIZE" env-default:"10000"`
+		ResultTTL time.Duration `env:"ANALYSIS_RESULTS_TTL" env-default:"1h"`
+	}
+
+	// Tickets limits the photos attached to a new ticket, they are uploaded
+	// UploadConcurrency at a time.
+	Tickets struct {
+		MaxImages         int `env:"TICKET_MAX_IMAGES" env-default:"5"`
+		UploadConcurrency int `env:"TICKET_UPLOAD_CONCURRENCY" env-default:"3"`
+	}
+
+	// Images bounds the photos accepted for tickets. The type is sniffed from
+	// the content, the Content-Type sent by the client is ignored.
+	Images struct {
+		AllowedTypes []string `env:"IMAGE_ALLOWED_TYPES" env-separator:"," env-default:"image/jpeg,image/png,image/heic,image/webp"`
+		MinWidth     int      `env:"IMAGE_MIN_WIDTH" env-default:"200"`
+		MinHeight    int      `env:"IMAGE_MIN_HEIGHT" env-default:"200"`
+		MaxWidth     int      `env:"IMAGE_MAX_WIDTH" env-default:"8192"`
+		MaxHeight    int      `env:"IMAGE_MAX_HEIGHT" env-default:"8192"`
+		MaxPixels    int      `env:"IMAGE_MAX_PIXELS" env-default:"50000000"`
+	}
+
+	// Events configures the ticket status stream. Changes are detected by
+	// listing the tickets of every subscribed user each PollInterval.
+	Events struct {
+		PollInterval time.Duration `env:"TICKET_EVENTS_POLL_INTERVAL" env-default:"5s"`
+		Heartbeat    time.Duration `env:"TICKET_EVENTS_HEARTBEAT" env-default:"15s"`
+		PageSize     int           `env:"TICKET_EVENTS_PAGE_SIZE" env-default:"100"`
+		MaxTickets   int           `env:"TICKET_EVENTS_MAX_TICKETS" env-default:"1000"`
+		Concurrency  int           `env:"TICKET_EVENTS_CONCURRENCY" env-default:"8"`
+	}
+
+	// Idempotency replays the first response to POST requests retried with
+	// the same Idempotency-Key header for TTL.
+	Idempotency struct {
+		Enabled bool          `env:"IDEMPOTENCY_ENABLED" env-default:"true"`
+		TTL     time.Duration `env:"IDEMPOTENCY_TTL" env-default:"24h"`
+		// MaxKeys bounds the keys kept in memory, the oldest are dropped first.
+		MaxKeys int `env:"IDEMPOTENCY_MAX_KEYS" env-default:"100000"`
+	}
+
+	UserCache struct {
+		Size int           `env:"USER_CACHE_SIZE" env-default:"10000"`
+		TTL  time.Duration `env:"USER_CACHE_TTL" env-default:"5m"`
+		// LoadTimeout bounds a lookup shared by concurrent requests.
+		LoadTimeout time.Duration `env:"USER_CACHE_LOAD_TIMEOUT" env-default:"10s"`
+	}
+
 	LogLevel string `env:"LOG_LEVEL" env-default:"debug"`
 }
 
+// Resilience is the call policy of a single upstream.
+type Resilience struct {
+	Timeout         time.Duration `env:"TIMEOUT" env-default:"10s"`
+	Retries         int           `env:"RETRIES" env-default:"2"`
+	BackoffBase     time.Duration `env:"BACKOFF_BASE" env-default:"50ms"`
+	BackoffMax      time.Duration `env:"BACKOFF_MAX" env-default:"1s"`
+	BreakerFailures int           `env:"BREAKER_FAILURES" env-default:"5"`
+	BreakerCooldown time.Duration `env:"BREAKER_COOLDOWN" env-default:"30s"`
+}
+
+type CORS struct {
+	// AllowOrigins may contain wildcard subdomains, e.g. https://*.example.com.
+	AllowOrigins     []string      `env:"CORS_ALLOW_ORIGINS" env-separator:"," env-default:"https://localhost:5173,http://localhost:5173"`
+	AllowMethods     []string      `env:"CORS_ALLOW_METHODS" env-separator:"," env-default:"GET,POST,HEAD,PUT,DELETE,PATCH"`
+	AllowHeaders     []string      `env:"CORS_ALLOW_HEADERS" env-separator:"," env-default:"Origin,Content-Type,Accept,Authorization,X-Request-Id,Idempotency-Key"`
+	ExposeHeaders    []string      `env:"CORS_EXPOSE_HEADERS" env-separator:"," env-default:"X-Request-Id,Retry-After,RateLimit-Limit,RateLimit-Remaining,RateLimit-Reset,Idempotent-Replayed"`
+	AllowCredentials bool          `env:"CORS_ALLOW_CREDENTIALS" env-default:"true"`
+	MaxAge           time.Duration `env:"CORS_MAX_AGE" env-default:"10m"`
+}
+
+// TLS secures the connection to a single upstream. Setting a client
+// certificate enables mTLS.
+type TLS struct {
+	Enabled    bool   `env:"TLS_ENABLED" env-default:"false"`
+	CAFile     string `env:"TLS_CA_FILE"`
+	CertFile   string `env:"TLS_CERT_FILE"`
+	KeyFile    string `env:"TLS_KEY_FILE"`
+	ServerName string `env:"TLS_SERVER_NAME"`
+}
+
+// Options returns the certificate options of an upstream dialed at host. The
+// server certificate must name host unless ServerName is set.
+func (t TLS) Options(host string) certs.Options {
+	name := t.ServerName
+	if name == "" {
+		name = host
+	}
+
+	return certs.Options{
+		Enabled:    t.Enabled,
+		CAFile:     t.CAFile,
+		CertFile:   t.CertFile,
+		KeyFile:    t.KeyFile,
+		ServerName: name,
+	}
+}
+
 func New() *Config {
 	config := &Config{}
 	if err := cleanenv.ReadEnv(config); err != nil {
@@ -50,5 +239,9 @@ func New() *Config {
 		panic(err)
 	}
 
+	if err := config.validate(); err != nil {
+		panic(err)
+	}
+
 	return config
 }
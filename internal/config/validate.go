@@ -0,0 +1,135 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// imageTypes lists the types imagecheck can measure.
+var imageTypes = []string{"image/jpeg", "image/png", "image/heic", "image/heif", "image/webp"}
+
+func (c *Config) validate() error {
+	if c.App.ProxyHeader != "" && len(c.App.TrustedProxies) == 0 {
+		return errors.New("APP_TRUSTED_PROXIES must be set when APP_PROXY_HEADER is, otherwise any client can spoof its address")
+	}
+	for _, p := range c.App.TrustedProxies {
+		if net.ParseIP(p) == nil {
+			if _, _, err := net.ParseCIDR(p); err != nil {
+				return fmt.Errorf("APP_TRUSTED_PROXIES: %q is neither an IP address nor a CIDR range", p)
+			}
+		}
+	}
+
+	if c.Auth.JWT.JWKS != "" && c.Auth.JWT.JWKSRefresh <= 0 {
+		return errors.New("AUTH_JWT_JWKS_REFRESH must be positive")
+	}
+
+	if c.Tickets.MaxImages < 1 {
+		return errors.New("TICKET_MAX_IMAGES must be at least 1")
+	}
+	if c.Tickets.UploadConcurrency < 1 {
+		return errors.New("TICKET_UPLOAD_CONCURRENCY must be at least 1")
+	}
+
+	if c.Events.PollInterval <= 0 {
+		return errors.New("TICKET_EVENTS_POLL_INTERVAL must be positive")
+	}
+	if c.Events.Heartbeat <= 0 {
+		return errors.New("TICKET_EVENTS_HEARTBEAT must be positive")
+	}
+	if c.Events.PageSize < 1 {
+		return errors.New("TICKET_EVENTS_PAGE_SIZE must be at least 1")
+	}
+	if c.Events.Concurrency < 1 {
+		return errors.New("TICKET_EVENTS_CONCURRENCY must be at least 1")
+	}
+
+	if c.Idempotency.MaxKeys < 1 {
+		return errors.New("IDEMPOTENCY_MAX_KEYS must be at least 1")
+	}
+
+	if c.Images.MinWidth > c.Images.MaxWidth || c.Images.MinHeight > c.Images.MaxHeight {
+		return errors.New("IMAGE_MIN_WIDTH and IMAGE_MIN_HEIGHT must not exceed IMAGE_MAX_WIDTH and IMAGE_MAX_HEIGHT")
+	}
+	if c.Images.MaxPixels < c.Images.MinWidth*c.Images.MinHeight {
+		return errors.New("IMAGE_MAX_PIXELS must allow an image of IMAGE_MIN_WIDTH by IMAGE_MIN_HEIGHT")
+	}
+	if len(c.Images.AllowedTypes) == 0 {
+		return errors.New("IMAGE_ALLOWED_TYPES must not be empty")
+	}
+	for _, t := range c.Images.AllowedTypes {
+		if !slices.Contains(imageTypes, t) {
+			return fmt.Errorf("IMAGE_ALLOWED_TYPES: %q is not supported, expected one of %s", t, strings.Join(imageTypes, ", "))
+		}
+	}
+
+	return c.CORS.validate()
+}
+
+func (c CORS) validate() error {
+	if len(c.AllowOrigins) == 0 {
+		return errors.New("CORS_ALLOW_ORIGINS must not be empty")
+	}
+
+	// the CORS middleware trims every entry, so "a, b" is fine
+	for _, origin := range c.AllowOrigins {
+		origin = strings.TrimSpace(origin)
+		if origin == "*" {
+			if len(c.AllowOrigins) > 1 {
+				return errors.New("CORS_ALLOW_ORIGINS cannot list * together with other origins")
+			}
+			if c.AllowCredentials {
+				return errors.New("CORS_ALLOW_ORIGINS cannot be * while CORS_ALLOW_CREDENTIALS is enabled")
+			}
+			continue
+		}
+
+		if err := validateOrigin(origin); err != nil {
+			return fmt.Errorf("CORS_ALLOW_ORIGINS: %w", err)
+		}
+	}
+
+	for _, method := range c.AllowMethods {
+		switch strings.TrimSpace(method) {
+		case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+			http.MethodPatch, http.MethodDelete, http.MethodOptions:
+		default:
+			return fmt.Errorf("CORS_ALLOW_METHODS: unsupported method %q", method)
+		}
+	}
+
+	if c.MaxAge < 0 {
+		return errors.New("CORS_MAX_AGE must not be negative")
+	}
+
+	return nil
+}
+
+// validateOrigin accepts scheme://host[:port] where the host may start with a
+// "*." wildcard matching any subdomain.
+func validateOrigin(origin string) error {
+	o := strings.Replace(origin, "://*.", "://", 1)
+	if strings.Contains(o, "*") {
+		return fmt.Errorf("origin %q: wildcards are only allowed as the first label of the host", origin)
+	}
+
+	u, err := url.Parse(o)
+	if err != nil {
+		return fmt.Errorf("origin %q: %w", origin, err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("origin %q: scheme must be http or https", origin)
+	}
+
+	if u.Host == "" || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("origin %q: expected scheme://host[:port]", origin)
+	}
+
+	return nil
+}
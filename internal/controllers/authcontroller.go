@@ -5,15 +5,17 @@ import (
 	"errors"
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
 	"github.com/mzhn-sochi/gateway/api/auth"
+	"github.com/mzhn-sochi/gateway/internal/apierror"
 	"github.com/mzhn-sochi/gateway/internal/entity"
 	"github.com/mzhn-sochi/gateway/internal/entity/dto"
 	"github.com/mzhn-sochi/gateway/internal/service/authservice"
+	"github.com/mzhn-sochi/gateway/pkg/cache"
 	"github.com/mzhn-sochi/gateway/pkg/middleware"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"log/slog"
 	"strings"
+	"time"
 )
 
 type AuthService interface {
@@ -26,19 +28,35 @@ type AuthService interface {
 
 type UserService interface {
 	FindById(ctx context.Context, id string) (*entity.User, error)
+	InvalidateUsers(ids ...string)
+	PurgeUsers()
+	UserCacheStats() cache.Stats
+}
+
+// LoginGuard tracks failed sign-ins and locks out phone numbers and client
+// addresses that keep guessing. Begin counts the attempt as failed until
+// Succeeded or Aborted says otherwise.
+type LoginGuard interface {
+	Begin(phone, ip string) time.Duration
+	Succeeded(phone, ip string)
+	Aborted(phone, ip string)
 }
 
 type AuthController struct {
 	service     AuthService
 	userService UserService
+	guard       LoginGuard
+	errors      *apierror.Mapping
 	validator   *validator.Validate
 }
 
-func NewAuthController(service AuthService, userService UserService) *AuthController {
+func NewAuthController(service AuthService, userService UserService, guard LoginGuard, mapping *apierror.Mapping) *AuthController {
 	return &AuthController{
 		service:     service,
-		validator:   validator.New(),
+		validator:   newValidator(),
 		userService: userService,
+		guard:       guard,
+		errors:      mapping,
 	}
 }
 
@@ -57,12 +75,19 @@ func (a *AuthController) SignIn() fiber.Handler {
 
 		if err := ctx.BodyParser(&req); err != nil {
 			logger.Error("failed to parse request", slog.String("err", err.Error()))
-			return internal(err.Error())
+			return bad(err.Error())
 		}
 
 		if err := a.validator.Struct(req); err != nil {
 			logger.Debug("failed to validate request", slog.String("err", err.Error()))
-			return bad(err.Error())
+			return err
+		}
+
+		// the guard keeps the address, it must not point into the request
+		ip := utils.CopyString(ctx.IP())
+		if wait := a.guard.Begin(req.Phone, ip); wait > 0 {
+			logger.Info("sign in locked", slog.Duration("wait", wait))
+			return tooManyRequests("too many failed sign in attempts", wait)
 		}
 
 		credentials := &entity.UserCredentials{
@@ -72,15 +97,31 @@ func (a *AuthController) SignIn() fiber.Handler {
 
 		tokens, err := a.service.SignIn(ctx.Context(), credentials)
 		if err != nil {
+			if !a.rejected(err) {
+				a.guard.Aborted(req.Phone, ip)
+			}
+
 			logger.Error("failed to sign in", slog.String("err", err.Error()))
-			return internal(err.Error())
+			return err
 		}
 
+		a.guard.Succeeded(req.Phone, ip)
+
 		return ok(ctx, tokens)
 
 	}
 }
 
+// rejected reports whether the credentials were refused, as opposed to the
+// auth service failing to answer.
+func (a *AuthController) rejected(err error) bool {
+	switch a.errors.StatusCode(err) {
+	case fiber.StatusUnauthorized, fiber.StatusForbidden, fiber.StatusNotFound:
+		return true
+	}
+	return false
+}
+
 func (a *AuthController) SignUp() fiber.Handler {
 
 	type request struct {
@@ -99,12 +140,12 @@ func (a *AuthController) SignUp() fiber.Handler {
 
 		if err := ctx.BodyParser(&req); err != nil {
 			logger.Error("failed to parse request", slog.String("err", err.Error()))
-			return internal(err.Error())
+			return bad(err.Error())
 		}
 
 		if err := a.validator.Struct(req); err != nil {
 			logger.Debug("failed to validate request", slog.String("err", err.Error()))
-			return bad(err.Error())
+			return err
 		}
 
 		u := &dto.RegisterUser{
@@ -119,16 +160,8 @@ func (a *AuthController) SignUp() fiber.Handler {
 
 		tokens, err := a.service.SignUp(ctx.Context(), u)
 		if err != nil {
-
-			if e, ok := status.FromError(err); ok {
-				switch e.Code() {
-				case codes.AlreadyExists:
-					return bad(e.Message())
-				}
-			}
-
-			logger.Error("failed to sign up", slog.String("err", err.Error()))
-			return internal(err.Error())
+			logger.Debug("failed to sign up", slog.String("err", err.Error()))
+			return err
 		}
 
 		return ok(ctx, tokens)
@@ -139,7 +172,7 @@ func (a *AuthController) SignOut() fiber.Handler {
 	return func(ctx *fiber.Ctx) error {
 		logger := ctx.Context().Value(middleware.LOGGER).(*slog.Logger).With("controller", "auth").With("method", "signOut")
 
-		accessToken, k := ctx.Locals("accessToken").(string)
+		accessToken, k := ctx.Locals(middleware.ACCESS_TOKEN).(string)
 		if !k {
 			logger.Error("missing access token")
 			return internal("missing access token")
@@ -147,7 +180,7 @@ func (a *AuthController) SignOut() fiber.Handler {
 
 		if err := a.service.SignOut(ctx.Context(), accessToken); err != nil {
 			logger.Error("failed to sign out", slog.String("err", err.Error()))
-			return internal(err.Error())
+			return err
 		}
 
 		return ok(ctx)
@@ -167,18 +200,18 @@ func (a *AuthController) Refresh() fiber.Handler {
 
 		if err := ctx.BodyParser(&r); err != nil {
 			logger.Error("failed to parse request", slog.String("err", err.Error()))
-			return internal(err.Error())
+			return bad(err.Error())
 		}
 
 		if err := a.validator.Struct(r); err != nil {
 			logger.Debug("failed to validate request", slog.String("err", err.Error()))
-			return bad(err.Error())
+			return err
 		}
 
 		tokens, err := a.service.Refresh(ctx.Context(), r.RefreshToken)
 		if err != nil {
 			logger.Error("failed to refresh", slog.String("err", err.Error()))
-			return internal(err.Error())
+			return err
 		}
 
 		return ok(ctx, tokens)
@@ -187,48 +220,44 @@ func (a *AuthController) Refresh() fiber.Handler {
 
 func (a *AuthController) AuthRequired(role auth.Role) fiber.Handler {
 	return func(ctx *fiber.Ctx) error {
-		logger := ctx.Context().Value(middleware.LOGGER).(*slog.Logger).With("controller", "auth").With("method", "authRequired")
-
-		authorization := ctx.Get("Authorization")
-		logger.Debug("authorization", slog.String("authorization", authorization))
-
-		s := strings.Split(authorization, " ")
-		if len(s) < 2 {
-			logger.Debug("failed to parse authorization header")
-			return bad("failed to parse authorization header")
+		if err := a.authenticate(ctx, role); err != nil {
+			return err
 		}
 
-		accessToken := s[1]
-
-		u, err := a.service.Authenticate(ctx.Context(), accessToken, role)
-		if err != nil {
-
-			if errors.Is(err, authservice.ErrUnauthorized) {
-				return unauthorized(err.Error())
-			}
+		return ctx.Next()
+	}
+}
 
-			if errors.Is(err, authservice.ErrForbidden) {
-				return forbidden(err.Error())
-			}
+func (a *AuthController) authenticate(ctx *fiber.Ctx, role auth.Role) error {
+	logger := ctx.Context().Value(middleware.LOGGER).(*slog.Logger).With("controller", "auth").With("method", "authRequired")
 
-			if errors.Is(err, authservice.ErrNotFound) {
-				return unauthorized(err.Error())
-			}
+	authorization := ctx.Get("Authorization")
+	logger.Debug("authorization", slog.String("authorization", authorization))
 
-			if errors.Is(err, authservice.ErrInvalidRequest) {
-				return bad(err.Error())
-			}
+	s := strings.Split(authorization, " ")
+	if len(s) < 2 {
+		logger.Debug("failed to parse authorization header")
+		return unauthorized("failed to parse authorization header")
+	}
 
-			logger.Error("failed to authenticate", slog.String("err", err.Error()))
+	// the header is backed by a reused request buffer, while the token
+	// outlives the request as a cache key
+	accessToken := utils.CopyString(s[1])
 
-			return internal(err.Error())
+	u, err := a.service.Authenticate(ctx.Context(), accessToken, role)
+	if err != nil {
+		if errors.Is(err, authservice.ErrNotFound) {
+			return unauthorized(err.Error())
 		}
 
-		ctx.Locals("accessToken", accessToken)
-		ctx.Locals("user", u)
-
-		return ctx.Next()
+		logger.Debug("failed to authenticate", slog.String("err", err.Error()))
+		return err
 	}
+
+	ctx.Locals(middleware.ACCESS_TOKEN, accessToken)
+	ctx.Locals("user", u)
+
+	return nil
 }
 
 func (a *AuthController) Profile() fiber.Handler {
@@ -250,3 +279,27 @@ func (a *AuthController) Profile() fiber.Handler {
 		return ok(ctx, user)
 	}
 }
+
+func (a *AuthController) UserCacheStats() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		return ok(ctx, a.userService.UserCacheStats())
+	}
+}
+
+func (a *AuthController) InvalidateUserCache() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		logger := ctx.Context().Value(middleware.LOGGER).(*slog.Logger).With("controller", "auth").With("method", "invalidateUserCache")
+
+		id := ctx.Params("id", "")
+		if id == "" {
+			logger.Info("purge user cache")
+			a.userService.PurgeUsers()
+			return ok(ctx)
+		}
+
+		logger.Info("invalidate cached user", slog.String("userId", id))
+		a.userService.InvalidateUsers(id)
+
+		return ok(ctx)
+	}
+}
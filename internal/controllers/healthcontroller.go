@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mzhn-sochi/gateway/internal/health"
+	"github.com/mzhn-sochi/gateway/pkg/middleware"
+)
+
+type HealthChecker interface {
+	Check(ctx context.Context) *health.Report
+}
+
+type HealthController struct {
+	checker HealthChecker
+}
+
+func NewHealthController(checker HealthChecker) *HealthController {
+	return &HealthController{
+		checker: checker,
+	}
+}
+
+func (c *HealthController) Liveness() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		return ctx.JSON(fiber.Map{
+			"status": "ok",
+		})
+	}
+}
+
+func (c *HealthController) Readiness() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		logger := ctx.Context().Value(middleware.LOGGER).(*slog.Logger).With("controller", "health").With("method", "readiness")
+
+		report := c.checker.Check(ctx.UserContext())
+
+		// the endpoint is public, so the details of a failing dependency
+		// only go to the log
+		dependencies := make(map[string]string, len(report.Dependencies))
+		for name, d := range report.Dependencies {
+			if d.Healthy {
+				dependencies[name] = "ok"
+				continue
+			}
+
+			dependencies[name] = "down"
+			logger.Warn("dependency is down",
+				slog.String("dependency", name),
+				slog.Bool("required", d.Required),
+				slog.String("check", d.Check),
+				slog.String("state", d.State),
+				slog.String("breaker", d.Breaker),
+				slog.String("status", d.Status),
+				slog.String("err", d.Error),
+				slog.String("latency", d.Latency),
+			)
+		}
+
+		code := fiber.StatusOK
+		if !report.Ready {
+			code = fiber.StatusServiceUnavailable
+		}
+
+		return ctx.Status(code).JSON(fiber.Map{
+			"ready":        report.Ready,
+			"draining":     report.Draining,
+			"dependencies": dependencies,
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/mzhn-sochi/gateway/api/auth"
+	"github.com/mzhn-sochi/gateway/internal/entity"
+	"github.com/mzhn-sochi/gateway/pkg/middleware"
+	"log/slog"
+)
+
+// OwnerResolver returns the id of the user owning the resource addressed by the request.
+type OwnerResolver func(ctx *fiber.Ctx) (string, error)
+
+// Policy describes who may call a route.
+type Policy struct {
+	public bool
+	role   auth.Role
+	owner  OwnerResolver
+}
+
+func Public() Policy {
+	return Policy{public: true}
+}
+
+func RequireRole(role auth.Role) Policy {
+	return Policy{role: role}
+}
+
+// OwnerOrAdmin lets admins through and any other user only when they own the resource.
+func OwnerOrAdmin(owner OwnerResolver) Policy {
+	return Policy{role: auth.Role_user, owner: owner}
+}
+
+func (a *AuthController) Enforce(policy Policy) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if policy.public {
+			return ctx.Next()
+		}
+
+		if err := a.authenticate(ctx, policy.role); err != nil {
+			return err
+		}
+
+		if policy.owner == nil {
+			return ctx.Next()
+		}
+
+		logger := ctx.Context().Value(middleware.LOGGER).(*slog.Logger).With("controller", "auth").With("method", "enforce")
+
+		u := ctx.Locals("user").(*entity.UserClaims)
+		if u.Role == entity.Role(auth.Role_admin) {
+			return ctx.Next()
+		}
+
+		ownerId, err := policy.owner(ctx)
+		if err != nil {
+			return err
+		}
+
+		if ownerId != u.Id {
+			logger.Debug("user is not an owner", slog.String("userId", u.Id), slog.String("ownerId", ownerId))
+			return forbidden("forbidden")
+		}
+
+		return ctx.Next()
+	}
+}
@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mzhn-sochi/gateway/internal/entity"
+	"github.com/mzhn-sochi/gateway/pkg/file"
+	"github.com/mzhn-sochi/gateway/pkg/middleware"
+	"log/slog"
+)
+
+type PriceTagAnalyzer interface {
+	Analyze(ctx context.Context, reader file.Reader) (*entity.ImageInfo, error)
+}
+
+type PriceTagController struct {
+	analyzer PriceTagAnalyzer
+}
+
+func NewPriceTagController(analyzer PriceTagAnalyzer) *PriceTagController {
+	return &PriceTagController{
+		analyzer: analyzer,
+	}
+}
+
+func (c *PriceTagController) Analyze() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		logger := ctx.Locals(middleware.LOGGER).(*slog.Logger).With("service", "pricetags").With("method", "Analyze")
+		ctx.Locals(middleware.LOGGER, logger)
+
+		f, err := ctx.FormFile("pricetag")
+		if err != nil {
+			return bad(err.Error())
+		}
+
+		reader, err := f.Open()
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+
+		ctype := f.Header.Get("Content-Type")
+		logger.Debug("analyze file", slog.String("file", f.Filename), slog.String("content-type", ctype))
+
+		info, err := c.analyzer.Analyze(ctx.Context(), file.NewReader(reader, f.Size, ctype))
+		if err != nil {
+			logger.Debug("failed to analyze image", slog.String("err", err.Error()))
+			return err
+		}
+
+		return ok(ctx, info)
+	}
+}
@@ -1,25 +1,35 @@
 package controllers
 
-import "github.com/gofiber/fiber/v2"
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/mzhn-sochi/gateway/internal/apierror"
+	"time"
+)
 
 func internal(message string) error {
-	return fiber.NewError(fiber.StatusInternalServerError, message)
+	return apierror.New(fiber.StatusInternalServerError, apierror.CodeInternal, message)
 }
 
 func bad(message string) error {
-	return fiber.NewError(fiber.StatusBadRequest, message)
+	return apierror.New(fiber.StatusBadRequest, apierror.CodeBadRequest, message)
 }
 
 func unauthorized(message string) error {
-	return fiber.NewError(fiber.StatusUnauthorized, message)
+	return apierror.New(fiber.StatusUnauthorized, apierror.CodeUnauthenticated, message)
 }
 
 func forbidden(message string) error {
-	return fiber.NewError(fiber.StatusForbidden, message)
+	return apierror.New(fiber.StatusForbidden, apierror.CodePermissionDenied, message)
 }
 
 func notFound(message string) error {
-	return fiber.NewError(fiber.StatusNotFound, message)
+	return apierror.New(fiber.StatusNotFound, apierror.CodeNotFound, message)
+}
+
+func tooManyRequests(message string, retryAfter time.Duration) error {
+	e := apierror.New(fiber.StatusTooManyRequests, apierror.CodeTooManyRequests, message)
+	e.RetryAfter = retryAfter
+	return e
 }
 
 func ok(ctx *fiber.Ctx, data ...interface{}) error {
@@ -19,7 +19,7 @@ type SuggestionsController struct {
 func NewSuggestionsController(service SuggestionsService) *SuggestionsController {
 	return &SuggestionsController{
 		service:   service,
-		validator: validator.New(),
+		validator: newValidator(),
 	}
 }
 
@@ -41,12 +41,12 @@ func (c *SuggestionsController) GetSuggestions() fiber.Handler {
 		}
 
 		if err := c.validator.Struct(r); err != nil {
-			return bad(err.Error())
+			return err
 		}
 
 		suggestions, err := c.service.GetSuggestions(ctx.Context(), r.Lon, r.Lat, r.Count)
 		if err != nil {
-			return internal(err.Error())
+			return err
 		}
 
 		return ok(ctx, suggestions)
@@ -0,0 +1,207 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mzhn-sochi/gateway/internal/apierror"
+	"github.com/mzhn-sochi/gateway/internal/entity"
+	"github.com/mzhn-sochi/gateway/internal/imagecheck"
+	"github.com/mzhn-sochi/gateway/pkg/file"
+	"github.com/mzhn-sochi/gateway/pkg/middleware"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"sync"
+)
+
+// ticketImage is a photo of a new ticket waiting to be uploaded.
+type ticketImage struct {
+	role   entity.ImageRole
+	header *multipart.FileHeader
+
+	data []byte
+	// contentType is sniffed from data.
+	contentType string
+}
+
+func (i *ticketImage) reader() file.Reader {
+	return file.NewReader(bytes.NewReader(i.data), int64(len(i.data)), i.contentType)
+}
+
+// imageError tells why a single photo was not uploaded.
+type imageError struct {
+	File    string `json:"file"`
+	Role    string `json:"role"`
+	Code    string `json:"code"`
+	Check   string `json:"check,omitempty"`
+	Message string `json:"message"`
+}
+
+// ticketImages collects the photos of a new ticket from the form. Exactly one
+// price tag photo is required and it comes first.
+func (c *TicketController) ticketImages(form *multipart.Form) ([]*ticketImage, error) {
+	var images []*ticketImage
+
+	for _, role := range entity.ImageRoles {
+		for _, h := range form.File[string(role)] {
+			images = append(images, &ticketImage{role: role, header: h})
+		}
+	}
+
+	if n := len(form.File[string(entity.ImageRolePricetag)]); n != 1 {
+		return nil, bad(fmt.Sprintf("exactly one %s image is required, got %d", entity.ImageRolePricetag, n))
+	}
+
+	if len(images) > c.maxImages {
+		return nil, bad(fmt.Sprintf("at most %d images are accepted, got %d", c.maxImages, len(images)))
+	}
+
+	return images, nil
+}
+
+// readImages reads the photos into memory and validates their content. The
+// error lists every photo that was rejected and the check it failed.
+func (c *TicketController) readImages(images []*ticketImage) error {
+	var failed []imageError
+
+	for _, img := range images {
+		data, err := readFile(img.header)
+		if err != nil {
+			return err
+		}
+
+		ctype, err := c.images.Validate(data)
+		if err != nil {
+			var ve *imagecheck.Error
+			if !errors.As(err, &ve) {
+				return err
+			}
+
+			failed = append(failed, imageError{
+				File:    img.header.Filename,
+				Role:    string(img.role),
+				Code:    apierror.CodeBadRequest,
+				Check:   ve.Check,
+				Message: ve.Message,
+			})
+			continue
+		}
+
+		img.data, img.contentType = data, ctype
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+
+	e := apierror.New(fiber.StatusBadRequest, apierror.CodeBadRequest, fmt.Sprintf("%d of %d images are invalid", len(failed), len(images)))
+	if len(failed) == 1 {
+		e.Message = fmt.Sprintf("%s: %s check failed: %s", failed[0].File, failed[0].Check, failed[0].Message)
+	}
+	e.Details = failed
+
+	return e
+}
+
+func readFile(h *multipart.FileHeader) ([]byte, error) {
+	f, err := h.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return io.ReadAll(f)
+}
+
+// detach copies the request values services read into a plain context. The
+// *fasthttp.RequestCtx behind ctx.Context() must not be shared with other
+// goroutines.
+func detach(ctx *fiber.Ctx) (context.Context, context.CancelFunc) {
+	c, cancel := context.WithCancel(ctx.UserContext())
+	for _, key := range []string{middleware.LOGGER, middleware.REQUEST_ID, middleware.ACCESS_TOKEN} {
+		if v := ctx.Locals(key); v != nil {
+			c = context.WithValue(c, key, v)
+		}
+	}
+	return c, cancel
+}
+
+// uploadImages uploads the photos concurrently. When any of them fails the
+// uploaded ones are removed and the error lists every failed file. ctx is
+// shared by the uploads, see detach.
+func (c *TicketController) uploadImages(ctx context.Context, images []*ticketImage) ([]entity.Image, error) {
+	var (
+		uploaded = make([]entity.Image, len(images))
+		errs     = make([]error, len(images))
+		sem      = make(chan struct{}, c.uploads)
+		wg       sync.WaitGroup
+	)
+
+	for i, img := range images {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			url, err := c.fileUploader.Upload(ctx, img.reader())
+			uploaded[i], errs[i] = entity.Image{Url: url, Role: img.role}, err
+		}()
+	}
+	wg.Wait()
+
+	var (
+		failed []imageError
+		first  error
+		done   []entity.Image
+	)
+	for i, err := range errs {
+		if err == nil {
+			done = append(done, uploaded[i])
+			continue
+		}
+
+		if first == nil {
+			first = err
+		}
+
+		e := c.errors.From(err)
+		failed = append(failed, imageError{
+			File:    images[i].header.Filename,
+			Role:    string(images[i].role),
+			Code:    e.Code,
+			Message: e.Message,
+		})
+	}
+
+	if first == nil {
+		return uploaded, nil
+	}
+
+	c.removeImages(ctx, done)
+
+	e := *c.errors.From(first)
+	e.Message = fmt.Sprintf("failed to upload %d of %d images", len(failed), len(images))
+	e.Details = failed
+
+	return nil, &e
+}
+
+// removeImages deletes uploaded photos of a ticket that was not created. It
+// runs even if the client has gone away.
+func (c *TicketController) removeImages(ctx context.Context, images []entity.Image) {
+	ctx = context.WithoutCancel(ctx)
+	logger := ctx.Value(middleware.LOGGER).(*slog.Logger)
+
+	for _, img := range images {
+		if err := c.fileUploader.Delete(ctx, img.Url); err != nil {
+			logger.Error("failed to remove uploaded image", slog.String("object", img.Url), slog.String("err", err.Error()))
+			continue
+		}
+		logger.Debug("removed uploaded image", slog.String("object", img.Url))
+	}
+}
@@ -1,17 +1,24 @@
 package controllers
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
+	"github.com/mzhn-sochi/gateway/internal/apierror"
+	"github.com/mzhn-sochi/gateway/internal/config"
 	"github.com/mzhn-sochi/gateway/internal/entity"
 	"github.com/mzhn-sochi/gateway/internal/entity/dto"
+	"github.com/mzhn-sochi/gateway/internal/service/authservice"
 	"github.com/mzhn-sochi/gateway/internal/service/ticketservice"
 	"github.com/mzhn-sochi/gateway/pkg/file"
 	"github.com/mzhn-sochi/gateway/pkg/middleware"
 	"log/slog"
+	"strings"
+	"time"
 )
 
 type TicketsService interface {
@@ -20,14 +27,20 @@ type TicketsService interface {
 	Create(ctx context.Context, createTicketDto *dto.CreateTicket) (string, error)
 
 	Close(ctx context.Context, id string) error
+	Approve(ctx context.Context, id string) error
+	Reject(ctx context.Context, id, reason string) error
+	UpdateItem(ctx context.Context, id string, update *dto.UpdateItem) (*entity.Ticket, error)
 }
 
 type FileUploader interface {
 	Upload(ctx context.Context, reader file.Reader) (string, error)
+	Delete(ctx context.Context, name string) error
 }
 
 type UserFinder interface {
 	FindById(ctx context.Context, id string) (*entity.User, error)
+	FindByIds(ctx context.Context, ids []string) (map[string]*entity.User, error)
+	FindIdByPhone(ctx context.Context, phone string) (string, error)
 }
 
 type SummaryService interface {
@@ -36,11 +49,39 @@ type SummaryService interface {
 	StatusSummary(ctx context.Context) (map[string]int64, error)
 }
 
+// TicketAnalysis recognizes price tags of new tickets in the background. A
+// place in its queue is reserved before the ticket is created and either
+// used by Submit or given back by Release.
+type TicketAnalysis interface {
+	Enabled() bool
+	Reserve() error
+	Release()
+	Submit(ctx context.Context, ticketId string, image []byte, contentType string) *entity.Analysis
+	Get(ticketId string) (*entity.Analysis, bool)
+}
+
+// ImageValidator checks photos by their content and returns their real type.
+type ImageValidator interface {
+	Validate(data []byte) (string, error)
+}
+
+// TicketEvents streams status changes of the tickets of a user.
+type TicketEvents interface {
+	Subscribe(userId string) (<-chan entity.TicketEvent, func())
+}
+
 type TicketController struct {
 	service      TicketsService
 	fileUploader FileUploader
 	userFinder   UserFinder
 	summary      SummaryService
+	analysis     TicketAnalysis
+	events       TicketEvents
+	images       ImageValidator
+	errors       *apierror.Mapping
+	heartbeat    time.Duration
+	maxImages    int
+	uploads      int
 
 	validator *validator.Validate
 }
@@ -50,13 +91,25 @@ func NewTicketController(
 	fileUploader FileUploader,
 	userFinder UserFinder,
 	summary SummaryService,
+	analysis TicketAnalysis,
+	events TicketEvents,
+	images ImageValidator,
+	mapping *apierror.Mapping,
+	config *config.Config,
 ) *TicketController {
 	return &TicketController{
 		service:      service,
-		validator:    validator.New(),
+		validator:    newValidator(),
 		fileUploader: fileUploader,
 		userFinder:   userFinder,
 		summary:      summary,
+		analysis:     analysis,
+		events:       events,
+		images:       images,
+		errors:       mapping,
+		heartbeat:    config.Events.Heartbeat,
+		maxImages:    config.Tickets.MaxImages,
+		uploads:      config.Tickets.UploadConcurrency,
 	}
 }
 
@@ -68,9 +121,9 @@ func (c *TicketController) Find() fiber.Handler {
 
 		ctx.Locals(middleware.LOGGER, logger)
 
-		t, err := c.service.Find(ctx.Context(), id)
+		t, err := c.find(ctx, id)
 		if err != nil {
-			return internal(err.Error())
+			return err
 		}
 
 		user, err := c.userFinder.FindById(ctx.Context(), t.UserId)
@@ -82,16 +135,52 @@ func (c *TicketController) Find() fiber.Handler {
 			User:   user,
 		}
 
+		// recent progress is newer than what the ticket stores
+		ticket.Analysis = t.Analysis
+		if a, k := c.analysis.Get(t.Id); k {
+			ticket.Analysis = a
+		}
+
 		return ok(ctx, ticket)
 	}
 }
 
+func (c *TicketController) Owner() OwnerResolver {
+	return func(ctx *fiber.Ctx) (string, error) {
+		t, err := c.find(ctx, ctx.Params("id"))
+		if err != nil {
+			return "", err
+		}
+
+		return t.UserId, nil
+	}
+}
+
+func (c *TicketController) find(ctx *fiber.Ctx, id string) (*entity.Ticket, error) {
+	if t, k := ctx.Locals("ticket").(*entity.Ticket); k && t.Id == id {
+		return t, nil
+	}
+
+	t, err := c.service.Find(ctx.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.Locals("ticket", t)
+
+	return t, nil
+}
+
 func (c *TicketController) List() fiber.Handler {
 
 	type query struct {
 		Limit  uint64 `query:"limit"`
 		Offset uint64 `query:"offset"`
 		UserId string `query:"userId"`
+		Status string `query:"status" validate:"omitempty,oneof=WAITING_OCR WAITING_VALIDATION WAITING_APPROVAL CLOSED REJECTED"`
+		From   int64  `query:"from" validate:"omitempty,gte=0"`
+		To     int64  `query:"to" validate:"omitempty,gte=0,gtefield=From"`
+		Phone  string `query:"phone" validate:"omitempty,len=11,numeric"`
 	}
 
 	type response struct {
@@ -102,13 +191,26 @@ func (c *TicketController) List() fiber.Handler {
 	return func(ctx *fiber.Ctx) error {
 		var q query
 		if err := ctx.QueryParser(&q); err != nil {
+			return bad(err.Error())
+		}
+
+		if err := c.validator.Struct(q); err != nil {
 			return err
 		}
 
 		logger := ctx.Locals(middleware.LOGGER).(*slog.Logger).With("service", "tickets").With("method", "List")
 		ctx.Locals(middleware.LOGGER, logger)
 
-		logger.Debug("list tickets", slog.Uint64("limit", q.Limit), slog.Uint64("offset", q.Offset), slog.String("userId", q.UserId))
+		logger.Debug(
+			"list tickets",
+			slog.Uint64("limit", q.Limit),
+			slog.Uint64("offset", q.Offset),
+			slog.String("userId", q.UserId),
+			slog.String("status", q.Status),
+			slog.Int64("from", q.From),
+			slog.Int64("to", q.To),
+			slog.String("phone", q.Phone),
+		)
 
 		filters := &entity.TicketFilters{
 			Filters: entity.Filters{
@@ -121,21 +223,57 @@ func (c *TicketController) List() fiber.Handler {
 			filters.UserId = &q.UserId
 		}
 
+		if q.Status != "" {
+			filters.Status = &q.Status
+		}
+
+		if q.From != 0 {
+			filters.From = &q.From
+		}
+
+		if q.To != 0 {
+			filters.To = &q.To
+		}
+
+		if q.Phone != "" {
+			filters.Phone = &q.Phone
+
+			userId, err := c.userFinder.FindIdByPhone(ctx.Context(), q.Phone)
+			if err != nil {
+				if errors.Is(err, authservice.ErrNotFound) {
+					return ok(ctx, &response{Tickets: make([]*dto.Ticket, 0)})
+				}
+				return err
+			}
+
+			if filters.UserId != nil && *filters.UserId != userId {
+				return ok(ctx, &response{Tickets: make([]*dto.Ticket, 0)})
+			}
+
+			filters.UserId = &userId
+		}
+
 		tt := make([]*dto.Ticket, 0)
 
 		tickets, total, err := c.service.List(ctx.Context(), filters)
 		if err != nil {
-			return internal(err.Error())
+			return err
+		}
+
+		ids := make([]string, 0, len(tickets))
+		for _, t := range tickets {
+			ids = append(ids, t.UserId)
+		}
+
+		users, err := c.findUsers(ctx.Context(), ids)
+		if err != nil {
+			return err
 		}
 
 		for _, t := range tickets {
-			user, err := c.userFinder.FindById(ctx.Context(), t.UserId)
-			if err != nil {
-				return notFound(err.Error())
-			}
 			ticket := &dto.Ticket{
 				Ticket: t,
-				User:   user,
+				User:   users[t.UserId],
 			}
 
 			tt = append(tt, ticket)
@@ -181,7 +319,7 @@ func (c *TicketController) ListUsers() fiber.Handler {
 
 		tickets, _, err := c.service.List(ctx.Context(), filters)
 		if err != nil {
-			return internal(err.Error())
+			return err
 		}
 
 		var user *entity.User
@@ -190,7 +328,7 @@ func (c *TicketController) ListUsers() fiber.Handler {
 			if user == nil {
 				user, err = c.userFinder.FindById(ctx.Context(), t.UserId)
 				if err != nil {
-					return notFound(err.Error())
+					return err
 				}
 			}
 
@@ -219,49 +357,172 @@ func (c *TicketController) Create() fiber.Handler {
 			return bad("shopName is required")
 		}
 
-		f, err := ctx.FormFile("pricetag")
+		form, err := ctx.MultipartForm()
 		if err != nil {
 			return bad(err.Error())
 		}
 
+		images, err := c.ticketImages(form)
+		if err != nil {
+			return err
+		}
+
 		logger := ctx.Locals(middleware.LOGGER).(*slog.Logger).With("service", "tickets").With("method", "Create")
 		ctx.Locals(middleware.LOGGER, logger)
 
-		reader, err := f.Open()
-		if err != nil {
-			return internal(err.Error())
+		u, k := ctx.Locals("user").(*entity.UserClaims)
+		if !k {
+			logger.Error("cannot get user from context")
+			return internal("cannot get user from context")
 		}
 
-		ctype := f.Header.Get("Content-Type")
-		logger.Debug("upload file", slog.String("file", f.Filename), slog.String("content-type", ctype))
+		// photos are checked before any of them is uploaded, the analysis
+		// shares the price tag that is now in memory
+		if err := c.readImages(images); err != nil {
+			logger.Debug("rejected ticket images", slog.String("err", err.Error()))
+			return err
+		}
+		pricetag := images[0]
+
+		// a full queue is reported before anything is uploaded or created
+		submit := c.analysis.Enabled()
+		if submit {
+			if err := c.analysis.Reserve(); err != nil {
+				logger.Warn("ticket analysis unavailable", slog.String("err", err.Error()))
+				return err
+			}
+			defer func() {
+				if submit {
+					c.analysis.Release()
+				}
+			}()
+		}
+
+		for _, img := range images {
+			logger.Debug("upload file", slog.String("file", img.header.Filename), slog.String("role", string(img.role)), slog.String("content-type", img.contentType))
+		}
+
+		uctx, cancel := detach(ctx)
+		defer cancel()
 
-		r := file.NewReader(reader, f.Size, ctype)
-		logger.Debug("upload file", slog.String("file", f.Filename))
+		uploaded, err := c.uploadImages(uctx, images)
+		if err != nil {
+			return err
+		}
+
+		createTicketDto := &dto.CreateTicket{
+			UserId:   u.Id,
+			ShopName: shopName,
+			ShopAddr: addr,
+			ImageUrl: uploaded[0].Url,
+			Images:   uploaded,
+		}
 
-		url, err := c.fileUploader.Upload(ctx.Context(), r)
+		ticketId, err := c.service.Create(ctx.Context(), createTicketDto)
 		if err != nil {
-			return internal(err.Error())
+			c.removeImages(uctx, uploaded)
+			return err
+		}
+
+		if !submit {
+			return ok(ctx, ticketId)
 		}
 
+		submit = false
+		a := c.analysis.Submit(ctx.Context(), ticketId, pricetag.data, pricetag.contentType)
+		logger.Debug("ticket analysis submitted", slog.String("ticketId", ticketId), slog.String("status", a.Status))
+
+		ctx.Location(fmt.Sprintf("%s/%s/analysis", ctx.Path(), ticketId))
+		return ctx.Status(fiber.StatusAccepted).JSON(&fiber.Map{
+			"data": fiber.Map{
+				"ticketId": ticketId,
+				"analysis": a,
+			},
+		})
+	}
+}
+
+// Events streams status changes of the tickets of the caller as Server-Sent
+// Events until the client goes away or the gateway shuts down.
+func (c *TicketController) Events() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		logger := ctx.Locals(middleware.LOGGER).(*slog.Logger).With("service", "tickets").With("method", "Events")
+
 		u, k := ctx.Locals("user").(*entity.UserClaims)
 		if !k {
 			logger.Error("cannot get user from context")
 			return internal("cannot get user from context")
 		}
 
-		createTicketDto := &dto.CreateTicket{
-			UserId:   u.Id,
-			ShopName: shopName,
-			ShopAddr: addr,
-			ImageUrl: url,
+		events, cancel := c.events.Subscribe(u.Id)
+		logger.Debug("subscribed to ticket events", slog.String("userId", u.Id))
+
+		ctx.Set(fiber.HeaderContentType, "text/event-stream")
+		ctx.Set(fiber.HeaderCacheControl, "no-cache")
+		ctx.Set(fiber.HeaderConnection, "keep-alive")
+		ctx.Set("X-Accel-Buffering", "no")
+
+		heartbeat := c.heartbeat
+		ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			defer cancel()
+			defer logger.Debug("ticket events stream closed")
+
+			t := time.NewTicker(heartbeat)
+			defer t.Stop()
+
+			fmt.Fprintf(w, "retry: %d\n\n", heartbeat.Milliseconds())
+			if err := w.Flush(); err != nil {
+				return
+			}
+
+			for {
+				select {
+				case e, open := <-events:
+					if !open {
+						return
+					}
+
+					data, err := json.Marshal(e)
+					if err != nil {
+						logger.Error("failed to encode ticket event", slog.String("err", err.Error()))
+						continue
+					}
+
+					fmt.Fprintf(w, "event: status\ndata: %s\n\n", data)
+				case <-t.C:
+					fmt.Fprint(w, ": ping\n\n")
+				}
+
+				// a failed flush means the client is gone
+				if err := w.Flush(); err != nil {
+					return
+				}
+			}
+		})
+
+		return nil
+	}
+}
+
+// Analysis reports the progress of the background price tag recognition.
+func (c *TicketController) Analysis() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		id := ctx.Params("id")
+
+		if a, k := c.analysis.Get(id); k {
+			return ok(ctx, a)
 		}
 
-		ticketId, err := c.service.Create(ctx.Context(), createTicketDto)
+		// results of older tickets only live with the ticket
+		t, err := c.find(ctx, id)
 		if err != nil {
-			return internal(err.Error())
+			return err
+		}
+		if t.Analysis == nil {
+			return notFound("no analysis for this ticket")
 		}
 
-		return ok(ctx, ticketId)
+		return ok(ctx, t.Analysis)
 	}
 }
 
@@ -280,13 +541,120 @@ func (c *TicketController) CloseTicket() fiber.Handler {
 			if errors.Is(err, ticketservice.ErrTicketNotFound) {
 				return notFound("ticket not found for id " + id)
 			}
-			return internal(err.Error())
+			return err
+		}
+
+		return ok(ctx)
+	}
+}
+
+func (c *TicketController) Approve() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		logger := ctx.Locals(middleware.LOGGER).(*slog.Logger).With("service", "tickets").With("method", "Approve")
+
+		id := ctx.Params("id", "")
+		if id == "" {
+			return bad("id is required")
+		}
+
+		if err := c.service.Approve(ctx.Context(), id); err != nil {
+			logger.Debug("failed to approve ticket", slog.String("ticketId", id), slog.String("err", err.Error()))
+			return err
+		}
+
+		logger.Info("ticket approved", slog.String("ticketId", id), slog.String("moderator", moderator(ctx)))
+
+		return ok(ctx)
+	}
+}
+
+func (c *TicketController) Reject() fiber.Handler {
+
+	type request struct {
+		Reason string `json:"reason" validate:"required,max=500"`
+	}
+
+	return func(ctx *fiber.Ctx) error {
+		logger := ctx.Locals(middleware.LOGGER).(*slog.Logger).With("service", "tickets").With("method", "Reject")
+
+		id := ctx.Params("id", "")
+		if id == "" {
+			return bad("id is required")
+		}
+
+		var req request
+		if err := ctx.BodyParser(&req); err != nil {
+			return bad(err.Error())
+		}
+
+		req.Reason = strings.TrimSpace(req.Reason)
+		if err := c.validator.Struct(req); err != nil {
+			return err
+		}
+
+		if err := c.service.Reject(ctx.Context(), id, req.Reason); err != nil {
+			logger.Debug("failed to reject ticket", slog.String("ticketId", id), slog.String("err", err.Error()))
+			return err
 		}
 
+		logger.Info("ticket rejected", slog.String("ticketId", id), slog.String("moderator", moderator(ctx)))
+
 		return ok(ctx)
 	}
 }
 
+func (c *TicketController) UpdateItem() fiber.Handler {
+
+	type request struct {
+		Product     *string  `json:"product" validate:"omitempty,min=1,max=255"`
+		Description *string  `json:"description" validate:"omitempty,max=1000"`
+		Price       *float32 `json:"price" validate:"omitempty,gt=0"`
+		Amount      *float32 `json:"amount" validate:"omitempty,gt=0"`
+		Unit        *string  `json:"unit" validate:"omitempty,min=1,max=32"`
+		Overprice   *uint32  `json:"overprice"`
+	}
+
+	return func(ctx *fiber.Ctx) error {
+		logger := ctx.Locals(middleware.LOGGER).(*slog.Logger).With("service", "tickets").With("method", "UpdateItem")
+
+		id := ctx.Params("id", "")
+		if id == "" {
+			return bad("id is required")
+		}
+
+		var req request
+		if err := ctx.BodyParser(&req); err != nil {
+			return bad(err.Error())
+		}
+
+		if err := c.validator.Struct(req); err != nil {
+			return err
+		}
+
+		update := dto.UpdateItem(req)
+		if update == (dto.UpdateItem{}) {
+			return bad("at least one item field is required")
+		}
+
+		t, err := c.service.UpdateItem(ctx.Context(), id, &update)
+		if err != nil {
+			logger.Debug("failed to update ticket item", slog.String("ticketId", id), slog.String("err", err.Error()))
+			return err
+		}
+
+		logger.Info("ticket item updated", slog.String("ticketId", id), slog.String("moderator", moderator(ctx)))
+
+		return ok(ctx, t)
+	}
+}
+
+func moderator(ctx *fiber.Ctx) string {
+	if u, k := ctx.Locals("user").(*entity.UserClaims); k {
+		return u.Id
+	}
+	return ""
+}
+
 func (c *TicketController) ShopSummary() fiber.Handler {
 
 	type response struct {
@@ -299,7 +667,7 @@ func (c *TicketController) ShopSummary() fiber.Handler {
 
 		summary, err := c.summary.ShopSummary(ctx.Context())
 		if err != nil {
-			return internal(err.Error())
+			return err
 		}
 
 		res := &response{
@@ -328,17 +696,32 @@ func (c *TicketController) UserSummary() fiber.Handler {
 
 		summary, err := c.summary.UserSummary(ctx.Context())
 		if err != nil {
-			return internal(err.Error())
+			return err
 		}
 
 		res := &response{
 			Records: make([]*dto.SummaryRecord, 0, len(summary)),
 		}
 
+		ids := make([]string, 0, len(summary))
+		for userId := range summary {
+			ids = append(ids, userId)
+		}
+
+		users, err := c.findUsers(ctx.Context(), ids)
+		if err != nil {
+			return err
+		}
+
 		for userId, count := range summary {
-			user, err := c.userFinder.FindById(ctx.Context(), userId)
-			if err != nil {
-				return internal(err.Error())
+			user, k := users[userId]
+			if !k {
+				logger.Warn("user not found", slog.String("userId", userId))
+				res.Records = append(res.Records, &dto.SummaryRecord{
+					Key:   userId,
+					Count: count,
+				})
+				continue
 			}
 
 			res.Records = append(res.Records, &dto.SummaryRecord{
@@ -370,7 +753,7 @@ func (c *TicketController) StatusSummary() fiber.Handler {
 
 		summary, err := c.summary.StatusSummary(ctx.Context())
 		if err != nil {
-			return internal(err.Error())
+			return err
 		}
 		res.Records = make([]*dto.SummaryRecord, 0, len(summary))
 
@@ -384,3 +767,18 @@ func (c *TicketController) StatusSummary() fiber.Handler {
 		return ok(ctx, res)
 	}
 }
+
+func (c *TicketController) findUsers(ctx context.Context, ids []string) (map[string]*entity.User, error) {
+	seen := make(map[string]struct{}, len(ids))
+	unique := make([]string, 0, len(ids))
+
+	for _, id := range ids {
+		if _, k := seen[id]; k {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+
+	return c.userFinder.FindByIds(ctx, unique)
+}
@@ -0,0 +1,17 @@
+package entity
+
+const (
+	AnalysisQueued  = "queued"
+	AnalysisRunning = "running"
+	AnalysisDone    = "done"
+	AnalysisFailed  = "failed"
+)
+
+// Analysis is the progress of the price tag recognition of a ticket image.
+type Analysis struct {
+	TicketId  string     `json:"ticketId"`
+	Status    string     `json:"status"`
+	Result    *ImageInfo `json:"result,omitempty"`
+	Error     string     `json:"error,omitempty"`
+	UpdatedAt int64      `json:"updatedAt"`
+}
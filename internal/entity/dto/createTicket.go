@@ -1,8 +1,11 @@
 package dto
 
+import "github.com/mzhn-sochi/gateway/internal/entity"
+
 type CreateTicket struct {
-	UserId   string `json:"userId"`
-	ShopName string `json:"shopName"`
-	ShopAddr string `json:"shopAddr"`
-	ImageUrl string `json:"imageUrl"`
+	UserId   string         `json:"userId"`
+	ShopName string         `json:"shopName"`
+	ShopAddr string         `json:"shopAddr"`
+	ImageUrl string         `json:"imageUrl"`
+	Images   []entity.Image `json:"images"`
 }
@@ -5,4 +5,5 @@ import "github.com/mzhn-sochi/gateway/internal/entity"
 type Ticket struct {
 	*entity.Ticket
 	*entity.User
+	Analysis *entity.Analysis `json:"analysis,omitempty"`
 }
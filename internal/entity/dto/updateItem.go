@@ -0,0 +1,12 @@
+package dto
+
+// UpdateItem carries a moderator's correction of the recognized item.
+// Nil fields keep their current value.
+type UpdateItem struct {
+	Product     *string  `json:"product"`
+	Description *string  `json:"description"`
+	Price       *float32 `json:"price"`
+	Amount      *float32 `json:"amount"`
+	Unit        *string  `json:"unit"`
+	Overprice   *uint32  `json:"overprice"`
+}
@@ -0,0 +1,9 @@
+package entity
+
+// TicketEvent tells that a ticket moved to another status.
+type TicketEvent struct {
+	TicketId string        `json:"ticketId"`
+	Previous *TicketStatus `json:"previous,omitempty"`
+	Status   TicketStatus  `json:"status"`
+	At       int64         `json:"at"`
+}
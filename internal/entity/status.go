@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// TicketStatus mirrors ts.Statuses and is encoded by name in JSON.
+type TicketStatus int32
+
+const (
+	StatusWaitingOCR TicketStatus = iota
+	StatusWaitingValidation
+	StatusWaitingApproval
+	StatusClosed
+	StatusRejected
+)
+
+var ticketStatusNames = map[TicketStatus]string{
+	StatusWaitingOCR:        "WAITING_OCR",
+	StatusWaitingValidation: "WAITING_VALIDATION",
+	StatusWaitingApproval:   "WAITING_APPROVAL",
+	StatusClosed:            "CLOSED",
+	StatusRejected:          "REJECTED",
+}
+
+// TicketStatuses lists every known status in workflow order.
+var TicketStatuses = []TicketStatus{
+	StatusWaitingOCR,
+	StatusWaitingValidation,
+	StatusWaitingApproval,
+	StatusClosed,
+	StatusRejected,
+}
+
+func (s TicketStatus) String() string {
+	if name, ok := ticketStatusNames[s]; ok {
+		return name
+	}
+	return strconv.Itoa(int(s))
+}
+
+// Final reports whether the ticket can no longer change.
+func (s TicketStatus) Final() bool {
+	return s == StatusClosed || s == StatusRejected
+}
+
+func ParseTicketStatus(name string) (TicketStatus, error) {
+	for s, n := range ticketStatusNames {
+		if n == name {
+			return s, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown ticket status %q", name)
+}
+
+// MarshalText writes statuses added upstream after this gateway by number, so
+// that they do not break whole responses.
+func (s TicketStatus) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText accepts the names of known statuses and the numbers written
+// by MarshalText for unknown ones.
+func (s *TicketStatus) UnmarshalText(text []byte) error {
+	if n, err := strconv.ParseInt(string(text), 10, 32); err == nil {
+		*s = TicketStatus(n)
+		return nil
+	}
+
+	v, err := ParseTicketStatus(string(text))
+	if err != nil {
+		return err
+	}
+	*s = v
+	return nil
+}
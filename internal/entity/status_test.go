@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTicketStatusJSON(t *testing.T) {
+	tests := []struct {
+		status TicketStatus
+		json   string
+	}{
+		{StatusWaitingOCR, `"WAITING_OCR"`},
+		{StatusWaitingValidation, `"WAITING_VALIDATION"`},
+		{StatusWaitingApproval, `"WAITING_APPROVAL"`},
+		{StatusClosed, `"CLOSED"`},
+		{StatusRejected, `"REJECTED"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.json, func(t *testing.T) {
+			data, err := json.Marshal(tt.status)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if string(data) != tt.json {
+				t.Fatalf("Marshal = %s, want %s", data, tt.json)
+			}
+
+			// start from a value that no case expects, so that a no-op
+			// Unmarshal is caught
+			s := TicketStatus(-1)
+			if err := json.Unmarshal(data, &s); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if s != tt.status {
+				t.Fatalf("Unmarshal = %d, want %d", s, tt.status)
+			}
+		})
+	}
+
+	if len(tests) != len(TicketStatuses) {
+		t.Fatalf("%d statuses tested, %d known", len(tests), len(TicketStatuses))
+	}
+}
+
+func TestTicketStatusJSONInStruct(t *testing.T) {
+	type ticket struct {
+		Status TicketStatus `json:"status"`
+	}
+
+	data, err := json.Marshal(ticket{})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != `{"status":"WAITING_OCR"}` {
+		t.Fatalf("Marshal = %s", data)
+	}
+
+	got := ticket{Status: StatusClosed}
+	if err := json.Unmarshal([]byte(`{"status":"WAITING_OCR"}`), &got); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if got.Status != StatusWaitingOCR {
+		t.Fatalf("Unmarshal = %s, want %s", got.Status, StatusWaitingOCR)
+	}
+}
+
+func TestTicketStatusUnknown(t *testing.T) {
+	data, err := json.Marshal(TicketStatus(7))
+	if err != nil {
+		t.Fatalf("Marshal of an unknown status failed: %v", err)
+	}
+	if string(data) != `"7"` {
+		t.Fatalf("Marshal = %s, want \"7\"", data)
+	}
+
+	var s TicketStatus
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatalf("Unmarshal of an unknown status failed: %v", err)
+	}
+	if s != 7 {
+		t.Fatalf("Unmarshal = %d, want 7", s)
+	}
+
+	for _, in := range []string{`"closed"`, `""`, `"PENDING"`, `"7.5"`} {
+		var s TicketStatus
+		if err := json.Unmarshal([]byte(in), &s); err == nil {
+			t.Errorf("Unmarshal(%s) = %s, want an error", in, s)
+		}
+	}
+}
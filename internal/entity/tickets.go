@@ -10,19 +10,39 @@ type TicketFilters struct {
 	Status *string `json:"status"`
 	UserId *string `json:"userId"`
 	Phone  *string `json:"phone"`
+	From   *int64  `json:"from"`
+	To     *int64  `json:"to"`
 }
 
 type Ticket struct {
-	Id          string  `json:"id"`
-	UserId      string  `json:"userId"`
-	Status      Role    `json:"status"`
-	ImageUrl    string  `json:"imageUrl"`
-	ShopName    string  `json:"shopName"`
-	ShopAddress string  `json:"shopAddress"`
-	CreatedAt   int64   `json:"createdAt"`
-	UpdatedAt   *int64  `json:"updatedAt"`
-	Reason      *string `json:"reason"`
-	Item        *Item   `json:"item"`
+	Id          string       `json:"id"`
+	UserId      string       `json:"userId"`
+	Status      TicketStatus `json:"status"`
+	ImageUrl    string       `json:"imageUrl"`
+	ShopName    string       `json:"shopName"`
+	ShopAddress string       `json:"shopAddress"`
+	CreatedAt   int64        `json:"createdAt"`
+	UpdatedAt   *int64       `json:"updatedAt"`
+	Reason      *string      `json:"reason"`
+	Item        *Item        `json:"item"`
+	Images      []Image      `json:"images"`
+	Analysis    *Analysis    `json:"analysis,omitempty"`
+}
+
+type ImageRole string
+
+const (
+	ImageRolePricetag ImageRole = "pricetag"
+	ImageRoleReceipt  ImageRole = "receipt"
+	ImageRoleShelf    ImageRole = "shelf"
+)
+
+// ImageRoles lists the form fields ticket photos are accepted under.
+var ImageRoles = []ImageRole{ImageRolePricetag, ImageRoleReceipt, ImageRoleShelf}
+
+type Image struct {
+	Url  string    `json:"url"`
+	Role ImageRole `json:"role"`
 }
 
 type Item struct {
@@ -0,0 +1,241 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/mzhn-sochi/gateway/internal/config"
+	"github.com/mzhn-sochi/gateway/internal/entity"
+	"github.com/mzhn-sochi/gateway/pkg/middleware"
+)
+
+const subscriberBuffer = 16
+
+type Lister interface {
+	List(ctx context.Context, filters *entity.TicketFilters) ([]*entity.Ticket, uint64, error)
+}
+
+type subscriber struct {
+	events chan entity.TicketEvent
+}
+
+// Poller detects status changes by listing the tickets of every subscribed
+// user on an interval and diffing them with the previous listing. All
+// subscribers of a user share one listing, and at most Concurrency users are
+// listed at a time.
+type Poller struct {
+	config *config.Config
+	logger *slog.Logger
+	lister Lister
+
+	mu     sync.Mutex
+	subs   map[string]map[*subscriber]struct{}
+	seen   map[string]map[string]entity.TicketStatus
+	closed bool
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewPoller(config *config.Config, logger *slog.Logger, lister Lister) *Poller {
+	p := &Poller{
+		config: config,
+		logger: logger.With("component", "events"),
+		lister: lister,
+		subs:   make(map[string]map[*subscriber]struct{}),
+		seen:   make(map[string]map[string]entity.TicketStatus),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+
+	go p.run()
+
+	return p
+}
+
+func (p *Poller) Subscribe(userId string) (<-chan entity.TicketEvent, func()) {
+	s := &subscriber{events: make(chan entity.TicketEvent, subscriberBuffer)}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		close(s.events)
+		return s.events, func() {}
+	}
+
+	if p.subs[userId] == nil {
+		p.subs[userId] = make(map[*subscriber]struct{})
+	}
+	p.subs[userId][s] = struct{}{}
+
+	var once sync.Once
+	return s.events, func() {
+		once.Do(func() { p.unsubscribe(userId, s) })
+	}
+}
+
+func (p *Poller) unsubscribe(userId string, s *subscriber) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.subs[userId][s]; !ok {
+		return
+	}
+
+	delete(p.subs[userId], s)
+	close(s.events)
+
+	if len(p.subs[userId]) == 0 {
+		delete(p.subs, userId)
+		delete(p.seen, userId)
+	}
+}
+
+// Close ends every subscription, which lets open streams finish before the
+// server drains its connections.
+func (p *Poller) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+
+	for userId, subs := range p.subs {
+		for s := range subs {
+			close(s.events)
+		}
+		delete(p.subs, userId)
+	}
+	p.mu.Unlock()
+
+	close(p.stop)
+	<-p.done
+}
+
+func (p *Poller) run() {
+	defer close(p.done)
+
+	t := time.NewTicker(p.config.Events.PollInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-t.C:
+			p.poll()
+		}
+	}
+}
+
+func (p *Poller) poll() {
+	p.mu.Lock()
+	users := make([]string, 0, len(p.subs))
+	for userId := range p.subs {
+		users = append(users, userId)
+	}
+	p.mu.Unlock()
+
+	var (
+		sem = make(chan struct{}, p.config.Events.Concurrency)
+		wg  sync.WaitGroup
+	)
+
+	for _, userId := range users {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			statuses, err := p.list(userId)
+			if err != nil {
+				p.logger.Warn("failed to list tickets", slog.String("userId", userId), slog.String("err", err.Error()))
+				return
+			}
+
+			p.diff(userId, statuses)
+		}()
+	}
+	wg.Wait()
+}
+
+func (p *Poller) list(userId string) (map[string]entity.TicketStatus, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.config.Events.PollInterval)
+	defer cancel()
+
+	ctx = context.WithValue(ctx, middleware.LOGGER, p.logger)
+
+	statuses := make(map[string]entity.TicketStatus)
+	limit := uint64(p.config.Events.PageSize)
+
+	for offset := uint64(0); offset < uint64(p.config.Events.MaxTickets); offset += limit {
+		filters := &entity.TicketFilters{
+			Filters: entity.Filters{Limit: limit, Offset: offset},
+			UserId:  &userId,
+		}
+
+		tickets, count, err := p.lister.List(ctx, filters)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range tickets {
+			statuses[t.Id] = t.Status
+		}
+
+		if len(tickets) == 0 || offset+limit >= count {
+			break
+		}
+	}
+
+	return statuses, nil
+}
+
+// diff compares the listing with the previous one and notifies subscribers.
+// The first listing of a user only becomes the baseline.
+func (p *Poller) diff(userId string, statuses map[string]entity.TicketStatus) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	subs, ok := p.subs[userId]
+	if !ok {
+		return
+	}
+
+	previous, ok := p.seen[userId]
+	p.seen[userId] = statuses
+	if !ok {
+		return
+	}
+
+	now := time.Now().Unix()
+	for id, status := range statuses {
+		prev, known := previous[id]
+		if known && prev == status {
+			continue
+		}
+
+		e := entity.TicketEvent{
+			TicketId: id,
+			Status:   status,
+			At:       now,
+		}
+		if known {
+			e.Previous = &prev
+		}
+
+		for s := range subs {
+			select {
+			case s.events <- e:
+			default:
+				p.logger.Warn("dropped ticket event of a slow subscriber", slog.String("userId", userId), slog.String("ticketId", id))
+			}
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package grpcconn
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/mzhn-sochi/gateway/pkg/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+func unaryLogger(logger *slog.Logger, upstream string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		logCall(ctx, logger, upstream, method, start, err)
+		return err
+	}
+}
+
+func streamLogger(logger *slog.Logger, upstream string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+		s, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			logCall(ctx, logger, upstream, method, start, err)
+		}
+		return s, err
+	}
+}
+
+// logCall logs through the request logger when the call is made on behalf
+// of an HTTP request, so the entry carries its request id.
+func logCall(ctx context.Context, logger *slog.Logger, upstream, method string, start time.Time, err error) {
+	if l, ok := ctx.Value(middleware.LOGGER).(*slog.Logger); ok {
+		logger = l
+	}
+
+	logger.Debug("upstream call",
+		slog.String("upstream", upstream),
+		slog.String("method", method),
+		slog.String("code", status.Code(err).String()),
+		slog.Duration("duration", time.Since(start)),
+	)
+}
@@ -0,0 +1,145 @@
+package grpcconn
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/mzhn-sochi/gateway/internal/config"
+	"github.com/mzhn-sochi/gateway/internal/metrics"
+	"github.com/mzhn-sochi/gateway/internal/resilience"
+	"github.com/mzhn-sochi/gateway/internal/tracing"
+	"github.com/mzhn-sochi/gateway/pkg/certs"
+	"github.com/mzhn-sochi/gateway/pkg/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+)
+
+// Target describes an upstream gRPC service.
+type Target struct {
+	Name       string
+	Host       string
+	Port       int
+	Resilience config.Resilience
+	TLS        config.TLS
+	// ForwardAuthorization sends the access token of the caller along with
+	// every call. Only upstreams that authenticate end users should see it.
+	ForwardAuthorization bool
+}
+
+// Manager owns every upstream connection of the gateway. Connections are
+// created lazily: nothing is dialed until the first call, and the host name
+// is resolved through DNS so that calls are balanced across all replicas.
+type Manager struct {
+	config     *config.Config
+	logger     *slog.Logger
+	metrics    *metrics.Metrics
+	tracing    *tracing.Tracing
+	resilience *resilience.Resilience
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+func New(config *config.Config, logger *slog.Logger, metrics *metrics.Metrics, tracing *tracing.Tracing, resilience *resilience.Resilience) (*Manager, error) {
+	switch config.GRPC.LoadBalancing {
+	case "round_robin", "pick_first":
+	default:
+		return nil, fmt.Errorf("unknown grpc load balancing policy %q", config.GRPC.LoadBalancing)
+	}
+
+	return &Manager{
+		config:     config,
+		logger:     logger.With("component", "grpcconn"),
+		metrics:    metrics,
+		tracing:    tracing,
+		resilience: resilience,
+		conns:      make(map[string]*grpc.ClientConn),
+	}, nil
+}
+
+func (m *Manager) Dial(t Target) (*grpc.ClientConn, error) {
+	l := m.logger.With(slog.String("upstream", t.Name))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.conns[t.Name]; ok {
+		return nil, fmt.Errorf("upstream %s is already registered", t.Name)
+	}
+
+	creds, err := certs.Credentials(t.TLS.Options(t.Host), l)
+	if err != nil {
+		return nil, fmt.Errorf("%s: load tls credentials: %w", t.Name, err)
+	}
+
+	conn, err := grpc.NewClient(fmt.Sprintf("dns:///%s:%d", t.Host, t.Port), m.options(t, creds)...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", t.Name, err)
+	}
+
+	l.Info("registered grpc upstream",
+		slog.String("host", t.Host),
+		slog.Int("port", t.Port),
+		slog.Bool("tls", t.TLS.Enabled),
+		slog.String("balancing", m.config.GRPC.LoadBalancing),
+	)
+
+	m.conns[t.Name] = conn
+
+	return conn, nil
+}
+
+// options returns the dial options shared by all upstreams. Interceptors run
+// in the listed order, so metrics and logs see the final outcome of a call
+// while every retry attempt gets its own client span.
+func (m *Manager) options(t Target, creds credentials.TransportCredentials) []grpc.DialOption {
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultServiceConfig(fmt.Sprintf(`{"loadBalancingConfig":[{"%s":{}}]}`, m.config.GRPC.LoadBalancing)),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    m.config.GRPC.KeepaliveTime,
+			Timeout: m.config.GRPC.KeepaliveTimeout,
+		}),
+		grpc.WithChainUnaryInterceptor(
+			m.metrics.UnaryClientInterceptor(t.Name),
+			unaryLogger(m.logger, t.Name),
+			middleware.RequestIdUnaryClientInterceptor(),
+		),
+		grpc.WithChainStreamInterceptor(
+			m.metrics.StreamClientInterceptor(t.Name),
+			streamLogger(m.logger, t.Name),
+			middleware.RequestIdStreamClientInterceptor(),
+		),
+	}
+	if t.ForwardAuthorization {
+		opts = append(opts,
+			grpc.WithChainUnaryInterceptor(middleware.AuthorizationUnaryClientInterceptor()),
+			grpc.WithChainStreamInterceptor(middleware.AuthorizationStreamClientInterceptor()),
+		)
+	}
+	opts = append(opts, m.resilience.DialOptions(t.Name, t.Resilience)...)
+	opts = append(opts, m.tracing.DialOptions()...)
+
+	return opts
+}
+
+// Close closes every connection. Calls still in flight fail with Canceled.
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var errs []error
+	for name, conn := range m.conns {
+		if err := conn.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+		delete(m.conns, name)
+	}
+
+	m.logger.Info("closed grpc upstreams")
+
+	return errors.Join(errs...)
+}
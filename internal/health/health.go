@@ -0,0 +1,169 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mzhn-sochi/gateway/internal/resilience"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	CheckHealthService = "grpc.health.v1"
+	CheckConnectivity  = "connectivity"
+)
+
+// Upstream is a gRPC dependency of the gateway.
+type Upstream interface {
+	Name() string
+	Conn() *grpc.ClientConn
+}
+
+// Breakers reports the circuit breaker state of an upstream by name.
+type Breakers interface {
+	BreakerState(upstream string) string
+}
+
+type Dependency struct {
+	Healthy  bool   `json:"healthy"`
+	Required bool   `json:"required"`
+	Check    string `json:"check"`
+	State    string `json:"state"`
+	Breaker  string `json:"breaker,omitempty"`
+	Status   string `json:"status,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Latency  string `json:"latency"`
+}
+
+type Report struct {
+	Ready        bool                   `json:"ready"`
+	Draining     bool                   `json:"draining,omitempty"`
+	Dependencies map[string]*Dependency `json:"dependencies"`
+}
+
+type Checker struct {
+	upstreams []Upstream
+	breakers  Breakers
+	optional  map[string]bool
+	timeout   time.Duration
+	draining  atomic.Bool
+}
+
+func NewChecker(timeout time.Duration, optional []string, breakers Breakers, upstreams ...Upstream) *Checker {
+	o := make(map[string]bool, len(optional))
+	for _, name := range optional {
+		o[name] = true
+	}
+
+	return &Checker{
+		upstreams: upstreams,
+		breakers:  breakers,
+		optional:  o,
+		timeout:   timeout,
+	}
+}
+
+// Drain makes every following check report the gateway as not ready, so
+// that load balancers stop sending traffic before it shuts down.
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+// Check probes every upstream concurrently. The goroutines share only ctx,
+// which bounds the whole check by the configured timeout.
+func (c *Checker) Check(ctx context.Context) *Report {
+	if c.draining.Load() {
+		return &Report{Draining: true, Dependencies: map[string]*Dependency{}}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	report := &Report{
+		Ready:        true,
+		Dependencies: make(map[string]*Dependency, len(c.upstreams)),
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+
+	for _, u := range c.upstreams {
+		wg.Add(1)
+		go func(u Upstream) {
+			defer wg.Done()
+
+			d := check(ctx, u.Conn())
+			d.Required = !c.optional[u.Name()]
+
+			// an open breaker rejects every call, whatever the upstream says
+			if c.breakers != nil {
+				d.Breaker = c.breakers.BreakerState(u.Name())
+				if d.Breaker == resilience.StateOpen {
+					d.Healthy = false
+				}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			report.Dependencies[u.Name()] = d
+			if d.Required && !d.Healthy {
+				report.Ready = false
+			}
+		}(u)
+	}
+
+	wg.Wait()
+
+	return report
+}
+
+// check asks the upstream through grpc.health.v1 and falls back to the
+// connectivity state when the upstream does not implement the health service.
+func check(ctx context.Context, conn *grpc.ClientConn) *Dependency {
+	start := time.Now()
+	d := &Dependency{Check: CheckHealthService}
+
+	res, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	switch {
+	case err == nil:
+		d.Status = res.Status.String()
+		d.Healthy = res.Status == healthpb.HealthCheckResponse_SERVING
+	case status.Code(err) == codes.Unimplemented:
+		d.Check = CheckConnectivity
+		d.Healthy = waitReady(ctx, conn)
+	default:
+		d.Error = err.Error()
+	}
+
+	d.State = conn.GetState().String()
+	d.Latency = time.Since(start).String()
+
+	return d
+}
+
+func waitReady(ctx context.Context, conn *grpc.ClientConn) bool {
+	conn.Connect()
+
+	for {
+		s := conn.GetState()
+		switch s {
+		case connectivity.Ready:
+			return true
+		case connectivity.Shutdown:
+			return false
+		}
+
+		if !conn.WaitForStateChange(ctx, s) {
+			return false
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package idempotency
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mzhn-sochi/gateway/internal/apierror"
+	"github.com/mzhn-sochi/gateway/internal/config"
+	"github.com/mzhn-sochi/gateway/internal/entity"
+	"github.com/mzhn-sochi/gateway/pkg/middleware"
+)
+
+const (
+	Header         = "Idempotency-Key"
+	HeaderReplayed = "Idempotent-Replayed"
+
+	maxKeyLength = 255
+)
+
+// replayedHeaders are kept along with the stored response body.
+var replayedHeaders = []string{fiber.HeaderContentType, fiber.HeaderLocation}
+
+// Keeper makes POST requests carrying an Idempotency-Key safe to retry: the
+// first response is stored and replayed for every retry with the same key.
+type Keeper struct {
+	store   Store
+	logger  *slog.Logger
+	enabled bool
+	ttl     time.Duration
+}
+
+func New(config *config.Config, logger *slog.Logger, store Store) *Keeper {
+	return &Keeper{
+		store:   store,
+		logger:  logger.With("component", "idempotency"),
+		enabled: config.Idempotency.Enabled,
+		ttl:     config.Idempotency.TTL,
+	}
+}
+
+func (k *Keeper) Handler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if !k.enabled || ctx.Method() != fiber.MethodPost {
+			return ctx.Next()
+		}
+
+		key := ctx.Get(Header)
+		if key == "" {
+			return ctx.Next()
+		}
+		if !valid(key) {
+			return apierror.New(fiber.StatusBadRequest, apierror.CodeBadRequest, fmt.Sprintf("%s must be 1-%d printable ASCII characters", Header, maxKeyLength))
+		}
+
+		logger := ctx.Locals(middleware.LOGGER).(*slog.Logger).With(slog.String("idempotencyKey", key))
+
+		fingerprint, err := fingerprint(ctx)
+		if err != nil {
+			return apierror.New(fiber.StatusBadRequest, apierror.CodeBadRequest, err.Error())
+		}
+
+		scoped := scope(ctx) + ":" + ctx.Route().Path + ":" + key
+
+		record, reserved, err := k.store.Reserve(ctx.Context(), scoped, fingerprint, k.ttl)
+		if err != nil {
+			// a broken store must not take the gateway down with it
+			k.logger.Error("failed to reserve idempotency key", slog.String("err", err.Error()))
+			return ctx.Next()
+		}
+
+		if !reserved {
+			switch {
+			case record.Fingerprint != fingerprint:
+				return apierror.New(fiber.StatusConflict, apierror.CodeConflict, Header+" was already used with a different request")
+			case record.Response == nil:
+				return apierror.New(fiber.StatusConflict, apierror.CodeConflict, "a request with this "+Header+" is still in progress")
+			}
+
+			logger.Debug("replay stored response")
+			return replay(ctx, record.Response)
+		}
+
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+			if err := k.store.Release(ctx.Context(), scoped); err != nil {
+				k.logger.Error("failed to release idempotency key", slog.String("err", err.Error()))
+			}
+		}()
+
+		if err := ctx.Next(); err != nil {
+			// render the error now so that it is stored like any other response
+			if err := ctx.App().Config().ErrorHandler(ctx, err); err != nil {
+				return err
+			}
+		}
+
+		status := ctx.Response().StatusCode()
+		if status >= fiber.StatusInternalServerError || status == fiber.StatusTooManyRequests {
+			// the request did not take effect and may be retried
+			return nil
+		}
+
+		if err := k.store.Complete(ctx.Context(), scoped, capture(ctx), k.ttl); err != nil {
+			k.logger.Error("failed to store idempotent response", slog.String("err", err.Error()))
+			return nil
+		}
+		completed = true
+
+		return nil
+	}
+}
+
+func replay(ctx *fiber.Ctx, r *Response) error {
+	for name, value := range r.Headers {
+		ctx.Set(name, value)
+	}
+	ctx.Set(HeaderReplayed, "true")
+
+	return ctx.Status(r.Status).Send(r.Body)
+}
+
+func capture(ctx *fiber.Ctx) *Response {
+	r := &Response{
+		Status:  ctx.Response().StatusCode(),
+		Headers: make(map[string]string),
+		Body:    append([]byte(nil), ctx.Response().Body()...),
+	}
+
+	for _, name := range replayedHeaders {
+		if v := ctx.Response().Header.Peek(name); len(v) > 0 {
+			r.Headers[name] = string(v)
+		}
+	}
+
+	return r
+}
+
+// scope keeps keys of different clients apart.
+func scope(ctx *fiber.Ctx) string {
+	if u, ok := ctx.Locals("user").(*entity.UserClaims); ok {
+		return "user:" + u.Id
+	}
+	return "ip:" + ctx.IP()
+}
+
+func valid(key string) bool {
+	if len(key) > maxKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// fingerprint hashes the request. Multipart bodies are hashed by their parts,
+// as clients pick a new boundary for every attempt.
+func fingerprint(ctx *fiber.Ctx) (string, error) {
+	h := sha256.New()
+	write(h, ctx.Method())
+	write(h, ctx.Path())
+
+	form, err := ctx.MultipartForm()
+	if err != nil {
+		h.Write(ctx.Body())
+		return hex.EncodeToString(h.Sum(nil)), nil
+	}
+
+	for _, name := range sorted(form.Value) {
+		write(h, name)
+		for _, v := range form.Value[name] {
+			write(h, v)
+		}
+	}
+
+	for _, name := range sorted(form.File) {
+		write(h, name)
+		for _, fh := range form.File[name] {
+			write(h, fh.Filename)
+
+			f, err := fh.Open()
+			if err != nil {
+				return "", fmt.Errorf("failed to read file %s: %w", fh.Filename, err)
+			}
+			_, err = io.Copy(h, f)
+			f.Close()
+			if err != nil {
+				return "", fmt.Errorf("failed to read file %s: %w", fh.Filename, err)
+			}
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func write(h hash.Hash, s string) {
+	fmt.Fprintf(h, "%d:%s;", len(s), s)
+}
+
+func sorted[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
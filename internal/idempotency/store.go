@@ -0,0 +1,139 @@
+package idempotency
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mzhn-sochi/gateway/internal/config"
+)
+
+// Response is what the gateway answered to the first request with a key.
+type Response struct {
+	Status  int
+	Headers map[string]string
+	Body    []byte
+}
+
+type Record struct {
+	Fingerprint string
+	// Response is nil while the first request is still being handled.
+	Response *Response
+}
+
+// Store keeps idempotency records. Reserve must be atomic per key so that
+// several gateway replicas can share a store, e.g. one backed by Redis SETNX.
+type Store interface {
+	// Reserve claims key for a new request. When the key is already taken it
+	// returns the existing record and false.
+	Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (*Record, bool, error)
+	// Complete stores the response of a reserved key.
+	Complete(ctx context.Context, key string, response *Response, ttl time.Duration) error
+	// Release frees a reserved key so that the request can be retried.
+	Release(ctx context.Context, key string) error
+}
+
+type entry struct {
+	key     string
+	record  Record
+	expires time.Time
+}
+
+const sweepInterval = time.Minute
+
+// MemoryStore keeps records of a single gateway instance. When it holds the
+// configured number of keys, the oldest reservation is dropped to make room,
+// so that clients cannot grow it without bound by sending fresh keys.
+type MemoryStore struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	// order holds the entries from the newest reservation to the oldest.
+	order *list.List
+	swept time.Time
+	now   func() time.Time
+}
+
+func NewMemoryStore(config *config.Config) *MemoryStore {
+	return &MemoryStore{
+		size:    config.Idempotency.MaxKeys,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryStore) Reserve(_ context.Context, key, fingerprint string, ttl time.Duration) (*Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	if el, ok := s.entries[key]; ok {
+		e := el.Value.(*entry)
+		if now.Before(e.expires) {
+			r := e.record
+			return &r, false, nil
+		}
+		s.remove(el)
+	}
+
+	s.entries[key] = s.order.PushFront(&entry{
+		key:     key,
+		record:  Record{Fingerprint: fingerprint},
+		expires: now.Add(ttl),
+	})
+
+	for s.order.Len() > s.size {
+		s.remove(s.order.Back())
+	}
+
+	return nil, true, nil
+}
+
+func (s *MemoryStore) Complete(_ context.Context, key string, response *Response, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	el, ok := s.entries[key]
+	if !ok {
+		return nil
+	}
+
+	e := el.Value.(*entry)
+	e.record.Response = response
+	e.expires = s.now().Add(ttl)
+
+	return nil
+}
+
+func (s *MemoryStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.entries[key]; ok {
+		s.remove(el)
+	}
+
+	return nil
+}
+
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.swept) < sweepInterval {
+		return
+	}
+	s.swept = now
+
+	for _, el := range s.entries {
+		if !now.Before(el.Value.(*entry).expires) {
+			s.remove(el)
+		}
+	}
+}
+
+func (s *MemoryStore) remove(el *list.Element) {
+	s.order.Remove(el)
+	delete(s.entries, el.Value.(*entry).key)
+}
@@ -0,0 +1,93 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mzhn-sochi/gateway/internal/config"
+)
+
+// newTestStore keeps at most size keys and reads the time from now, which
+// tests move forward.
+func newTestStore(size int, now *time.Time) *MemoryStore {
+	cfg := &config.Config{}
+	cfg.Idempotency.MaxKeys = size
+
+	s := NewMemoryStore(cfg)
+	s.now = func() time.Time { return *now }
+	return s
+}
+
+func reserve(t *testing.T, s *MemoryStore, key string) bool {
+	t.Helper()
+	_, ok, err := s.Reserve(context.Background(), key, "fp", time.Hour)
+	if err != nil {
+		t.Fatalf("Reserve(%q) failed: %v", key, err)
+	}
+	return ok
+}
+
+func TestMemoryStoreReplaysUntilExpiry(t *testing.T) {
+	now := time.Unix(1000, 0)
+	s := newTestStore(10, &now)
+
+	if !reserve(t, s, "a") {
+		t.Fatal("first Reserve was refused")
+	}
+
+	res := &Response{Status: 201, Body: []byte("{}")}
+	if err := s.Complete(context.Background(), "a", res, time.Minute); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+
+	r, ok, err := s.Reserve(context.Background(), "a", "fp", time.Hour)
+	if err != nil || ok {
+		t.Fatalf("second Reserve = %v, %v, want the stored record", ok, err)
+	}
+	if r.Response == nil || r.Response.Status != 201 {
+		t.Fatalf("second Reserve record = %+v, want the completed response", r)
+	}
+
+	now = now.Add(time.Minute)
+	if !reserve(t, s, "a") {
+		t.Fatal("Reserve after the ttl was refused")
+	}
+}
+
+func TestMemoryStoreDropsOldestKeys(t *testing.T) {
+	now := time.Unix(1000, 0)
+	s := newTestStore(2, &now)
+
+	for _, key := range []string{"a", "b", "c"} {
+		if !reserve(t, s, key) {
+			t.Fatalf("Reserve(%q) was refused", key)
+		}
+	}
+
+	if n := s.order.Len(); n != 2 || len(s.entries) != 2 {
+		t.Fatalf("store holds %d entries and %d keys, want 2", n, len(s.entries))
+	}
+	if !reserve(t, s, "a") {
+		t.Fatal("the oldest key is still reserved, want it dropped")
+	}
+	if reserve(t, s, "c") {
+		t.Fatal("the newest key was dropped")
+	}
+}
+
+func TestMemoryStoreRelease(t *testing.T) {
+	now := time.Unix(1000, 0)
+	s := newTestStore(10, &now)
+
+	reserve(t, s, "a")
+	if err := s.Release(context.Background(), "a"); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	if s.order.Len() != 0 {
+		t.Fatalf("store holds %d entries after Release, want 0", s.order.Len())
+	}
+	if !reserve(t, s, "a") {
+		t.Fatal("Reserve after Release was refused")
+	}
+}
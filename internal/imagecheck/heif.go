@@ -0,0 +1,102 @@
+package imagecheck
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var errHeifTruncated = errors.New("heif image is truncated")
+
+// heifBoxes walks the ISO BMFF boxes of data.
+func heifBoxes(data []byte, fn func(kind string, payload []byte) error) error {
+	for off := 0; off < len(data); {
+		if off+8 > len(data) {
+			return errHeifTruncated
+		}
+
+		size := uint64(binary.BigEndian.Uint32(data[off : off+4]))
+		kind := string(data[off+4 : off+8])
+		header := uint64(8)
+
+		switch size {
+		case 0:
+			// the box extends to the end of the file
+			size = uint64(len(data) - off)
+		case 1:
+			if off+16 > len(data) {
+				return errHeifTruncated
+			}
+			size = binary.BigEndian.Uint64(data[off+8 : off+16])
+			header = 16
+		}
+
+		if size < header || size > uint64(len(data)-off) {
+			return errHeifTruncated
+		}
+
+		if err := fn(kind, data[off+int(header):off+int(size)]); err != nil {
+			return err
+		}
+
+		off += int(size)
+	}
+
+	return nil
+}
+
+// heifSize reads the image spatial extents property. Thumbnails have their
+// own, so the largest one is taken as the primary image.
+func heifSize(data []byte) (Size, error) {
+	var size Size
+
+	var walk func(kind string, p []byte) error
+	walk = func(kind string, p []byte) error {
+		switch kind {
+		case "meta":
+			// full box: version and flags come first
+			if len(p) < 4 {
+				return errHeifTruncated
+			}
+			return heifBoxes(p[4:], walk)
+		case "iprp", "ipco":
+			return heifBoxes(p, walk)
+		case "ispe":
+			if len(p) < 12 {
+				return errHeifTruncated
+			}
+			w, h := int(binary.BigEndian.Uint32(p[4:8])), int(binary.BigEndian.Uint32(p[8:12]))
+			if w*h > size.Width*size.Height {
+				size = Size{w, h}
+			}
+		}
+		return nil
+	}
+
+	if err := heifBoxes(data, walk); err != nil {
+		return Size{}, err
+	}
+	if size.Width == 0 || size.Height == 0 {
+		return Size{}, errors.New("heif image has no dimensions")
+	}
+
+	return size, nil
+}
+
+func heifComplete(data []byte) error {
+	var media bool
+
+	err := heifBoxes(data, func(kind string, _ []byte) error {
+		if kind == "mdat" {
+			media = true
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !media {
+		return errors.New("heif image has no media data")
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package imagecheck
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+func be32(v uint32) []byte {
+	return binary.BigEndian.AppendUint32(nil, v)
+}
+
+func box(kind string, payload ...[]byte) []byte {
+	p := bytes.Join(payload, nil)
+	return append(append(be32(uint32(8+len(p))), kind...), p...)
+}
+
+// fullBox prepends version and flags.
+func fullBox(kind string, payload ...[]byte) []byte {
+	return box(kind, append([][]byte{make([]byte, 4)}, payload...)...)
+}
+
+func ispe(w, h uint32) []byte {
+	return fullBox("ispe", be32(w), be32(h))
+}
+
+func meta(props ...[]byte) []byte {
+	return fullBox("meta", box("iprp", box("ipco", props...)))
+}
+
+func ftyp(brand string) []byte {
+	return box("ftyp", []byte(brand), be32(0), []byte("mif1"+brand))
+}
+
+func heif(w, h uint32) []byte {
+	return bytes.Join([][]byte{ftyp("heic"), meta(ispe(w, h)), box("mdat", make([]byte, 32))}, nil)
+}
+
+func TestHeif(t *testing.T) {
+	image := heif(640, 480)
+
+	tests := []struct {
+		name     string
+		data     []byte
+		size     Size
+		sizeErr  error
+		complete error
+	}{
+		{
+			name: "image",
+			data: image,
+			size: Size{640, 480},
+		},
+		{
+			name: "largest extents win",
+			data: bytes.Join([][]byte{ftyp("heic"), meta(ispe(160, 120), ispe(640, 480), ispe(320, 240)), box("mdat")}, nil),
+			size: Size{640, 480},
+		},
+		{
+			name: "last box extends to the end",
+			data: bytes.Join([][]byte{ftyp("heic"), meta(ispe(640, 480)), be32(0), []byte("mdat"), make([]byte, 32)}, nil),
+			size: Size{640, 480},
+		},
+		{
+			name: "large size box",
+			data: bytes.Join([][]byte{ftyp("heic"), meta(ispe(640, 480)), be32(1), []byte("mdat"), binary.BigEndian.AppendUint64(nil, 16+4), make([]byte, 4)}, nil),
+			size: Size{640, 480},
+		},
+		{
+			name:     "truncated media",
+			data:     image[:len(image)-1],
+			sizeErr:  errHeifTruncated,
+			complete: errHeifTruncated,
+		},
+		{
+			name:     "truncated box header",
+			data:     append(append([]byte{}, image...), 0, 0, 0),
+			sizeErr:  errHeifTruncated,
+			complete: errHeifTruncated,
+		},
+		{
+			name:     "truncated large size",
+			data:     bytes.Join([][]byte{ftyp("heic"), meta(ispe(640, 480)), be32(1), []byte("mdat"), be32(0)}, nil),
+			sizeErr:  errHeifTruncated,
+			complete: errHeifTruncated,
+		},
+		{
+			name:     "large size beyond the data",
+			data:     bytes.Join([][]byte{ftyp("heic"), meta(ispe(640, 480)), be32(1), []byte("mdat"), binary.BigEndian.AppendUint64(nil, 1<<62)}, nil),
+			sizeErr:  errHeifTruncated,
+			complete: errHeifTruncated,
+		},
+		{
+			name:     "size below the header",
+			data:     bytes.Join([][]byte{ftyp("heic"), be32(4), []byte("mdat")}, nil),
+			sizeErr:  errHeifTruncated,
+			complete: errHeifTruncated,
+		},
+		{
+			name:    "short extents",
+			data:    bytes.Join([][]byte{ftyp("heic"), meta(fullBox("ispe", be32(640))), box("mdat")}, nil),
+			sizeErr: errHeifTruncated,
+		},
+		{
+			name:    "short meta",
+			data:    bytes.Join([][]byte{ftyp("heic"), box("meta", []byte{0, 0}), box("mdat")}, nil),
+			sizeErr: errHeifTruncated,
+		},
+		{
+			name:    "no extents",
+			data:    bytes.Join([][]byte{ftyp("heic"), meta(), box("mdat")}, nil),
+			sizeErr: errors.New("heif image has no dimensions"),
+		},
+		{
+			name:     "no media",
+			data:     bytes.Join([][]byte{ftyp("heic"), meta(ispe(640, 480))}, nil),
+			size:     Size{640, 480},
+			complete: errors.New("heif image has no media data"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			size, err := heifSize(tt.data)
+			if !sameError(err, tt.sizeErr) {
+				t.Fatalf("heifSize error = %v, want %v", err, tt.sizeErr)
+			}
+			if size != tt.size {
+				t.Fatalf("heifSize = %v, want %v", size, tt.size)
+			}
+
+			if err := heifComplete(tt.data); !sameError(err, tt.complete) {
+				t.Fatalf("heifComplete = %v, want %v", err, tt.complete)
+			}
+		})
+	}
+}
+
+// sameError compares errors by message, the parsers return unexported ones.
+func sameError(err, want error) bool {
+	if err == nil || want == nil {
+		return err == want
+	}
+	return err.Error() == want.Error()
+}
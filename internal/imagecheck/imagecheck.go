@@ -0,0 +1,128 @@
+package imagecheck
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/mzhn-sochi/gateway/internal/config"
+)
+
+// Checks an image can fail.
+const (
+	CheckEmpty      = "empty"
+	CheckType       = "type"
+	CheckCorrupt    = "corrupt"
+	CheckDimensions = "dimensions"
+)
+
+// Error tells which check an image failed.
+type Error struct {
+	Check   string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s check failed: %s", e.Check, e.Message)
+}
+
+type Size struct {
+	Width, Height int
+}
+
+// Validator checks uploaded photos by their content rather than by what the
+// client claims they are.
+type Validator struct {
+	allowed   map[string]bool
+	min       Size
+	max       Size
+	maxPixels int
+}
+
+func New(config *config.Config) *Validator {
+	c := config.Images
+
+	allowed := make(map[string]bool, len(c.AllowedTypes))
+	for _, t := range c.AllowedTypes {
+		allowed[t] = true
+	}
+
+	return &Validator{
+		allowed:   allowed,
+		min:       Size{c.MinWidth, c.MinHeight},
+		max:       Size{c.MaxWidth, c.MaxHeight},
+		maxPixels: c.MaxPixels,
+	}
+}
+
+// Validate sniffs the type of the image, checks that it is complete and that
+// its dimensions are within bounds. It returns the sniffed content type.
+func (v *Validator) Validate(data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", &Error{Check: CheckEmpty, Message: "image is empty"}
+	}
+
+	ctype, _, _ := strings.Cut(mimetype.Detect(data).String(), ";")
+	if !v.allowed[ctype] {
+		return "", &Error{Check: CheckType, Message: fmt.Sprintf("%s is not an accepted image type", ctype)}
+	}
+
+	size, err := dimensions(ctype, data)
+	if err != nil {
+		return "", &Error{Check: CheckCorrupt, Message: err.Error()}
+	}
+
+	if size.Width < v.min.Width || size.Height < v.min.Height {
+		return "", &Error{Check: CheckDimensions, Message: fmt.Sprintf("image is %dx%d, at least %dx%d is required", size.Width, size.Height, v.min.Width, v.min.Height)}
+	}
+	if size.Width > v.max.Width || size.Height > v.max.Height {
+		return "", &Error{Check: CheckDimensions, Message: fmt.Sprintf("image is %dx%d, at most %dx%d is accepted", size.Width, size.Height, v.max.Width, v.max.Height)}
+	}
+	if size.Width*size.Height > v.maxPixels {
+		return "", &Error{Check: CheckDimensions, Message: fmt.Sprintf("image is %dx%d, at most %d pixels are accepted", size.Width, size.Height, v.maxPixels)}
+	}
+
+	if err := complete(ctype, data); err != nil {
+		return "", &Error{Check: CheckCorrupt, Message: err.Error()}
+	}
+
+	return ctype, nil
+}
+
+func dimensions(ctype string, data []byte) (Size, error) {
+	switch ctype {
+	case "image/jpeg", "image/png":
+		c, _, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return Size{}, fmt.Errorf("failed to read image header: %w", err)
+		}
+		return Size{c.Width, c.Height}, nil
+	case "image/webp":
+		return webpSize(data)
+	case "image/heic", "image/heif":
+		return heifSize(data)
+	}
+
+	return Size{}, fmt.Errorf("dimensions of %s images are unknown", ctype)
+}
+
+// complete reports whether the whole image is present by walking its
+// structure. Images are never decoded, a small file may hold a huge bitmap.
+func complete(ctype string, data []byte) error {
+	switch ctype {
+	case "image/jpeg":
+		return jpegComplete(data)
+	case "image/png":
+		return pngComplete(data)
+	case "image/webp":
+		return webpComplete(data)
+	case "image/heic", "image/heif":
+		return heifComplete(data)
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package imagecheck
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func encodePNG(t testing.TB, w, h int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatalf("failed to encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func encodeJPEG(t testing.TB, w, h int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, w, h)), nil); err != nil {
+		t.Fatalf("failed to encode jpeg: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// withThumbnail puts a complete jpeg into an APP1 segment of image, the way
+// cameras embed thumbnails in EXIF.
+func withThumbnail(t testing.TB, image []byte) []byte {
+	t.Helper()
+	thumb := encodeJPEG(t, 8, 8)
+	n := len(thumb) + 2
+	segment := append([]byte{0xff, 0xe1, byte(n >> 8), byte(n)}, thumb...)
+	return append(append(append([]byte{}, image[:2]...), segment...), image[2:]...)
+}
+
+func testValidator() *Validator {
+	return &Validator{
+		allowed: map[string]bool{
+			"image/jpeg": true,
+			"image/png":  true,
+			"image/webp": true,
+			"image/heic": true,
+		},
+		min:       Size{10, 10},
+		max:       Size{100, 100},
+		maxPixels: 5000,
+	}
+}
+
+func TestValidate(t *testing.T) {
+	var (
+		pngImage  = encodePNG(t, 50, 50)
+		jpegImage = encodeJPEG(t, 50, 50)
+		thumbnail = withThumbnail(t, jpegImage)
+	)
+
+	tests := []struct {
+		name  string
+		data  []byte
+		ctype string
+		check string
+	}{
+		{name: "png", data: pngImage, ctype: "image/png"},
+		{name: "jpeg", data: jpegImage, ctype: "image/jpeg"},
+		{name: "jpeg with thumbnail", data: thumbnail, ctype: "image/jpeg"},
+		{name: "jpeg with trailer", data: append(append([]byte{}, jpegImage...), "trailer"...), ctype: "image/jpeg"},
+		{name: "webp", data: riff(vp8l(50, 50)), ctype: "image/webp"},
+		{name: "heic", data: heif(50, 50), ctype: "image/heic"},
+
+		{name: "empty", check: CheckEmpty},
+		{name: "text", data: []byte("not an image at all"), check: CheckType},
+		{name: "heif brand not allowed", data: bytes.Join([][]byte{ftyp("mif1"), meta(ispe(50, 50)), box("mdat")}, nil), check: CheckType},
+
+		{name: "truncated png", data: pngImage[:len(pngImage)-12], check: CheckCorrupt},
+		{name: "truncated jpeg", data: jpegImage[:len(jpegImage)-2], check: CheckCorrupt},
+		{name: "truncated jpeg with thumbnail", data: thumbnail[:len(thumbnail)-2], check: CheckCorrupt},
+		{name: "truncated webp", data: riff(vp8l(50, 50))[:20], check: CheckCorrupt},
+		{name: "truncated heic", data: heif(50, 50)[:60], check: CheckCorrupt},
+
+		{name: "too small", data: encodePNG(t, 5, 50), check: CheckDimensions},
+		{name: "too wide", data: encodePNG(t, 101, 10), check: CheckDimensions},
+		{name: "too many pixels", data: encodePNG(t, 80, 80), check: CheckDimensions},
+		{name: "huge header", data: riff(vp8x(1<<24, 1<<24)), check: CheckDimensions},
+	}
+
+	v := testValidator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctype, err := v.Validate(tt.data)
+
+			if tt.check == "" {
+				if err != nil {
+					t.Fatalf("Validate failed: %v", err)
+				}
+				if ctype != tt.ctype {
+					t.Fatalf("Validate = %s, want %s", ctype, tt.ctype)
+				}
+				return
+			}
+
+			var e *Error
+			if !errors.As(err, &e) || e.Check != tt.check {
+				t.Fatalf("Validate error = %v, want a failed %s check", err, tt.check)
+			}
+		})
+	}
+}
+
+func FuzzValidate(f *testing.F) {
+	for _, seed := range [][]byte{
+		encodePNG(f, 50, 50),
+		encodeJPEG(f, 50, 50),
+		withThumbnail(f, encodeJPEG(f, 50, 50)),
+		riff(vp8(50, 50)),
+		riff(vp8l(50, 50)),
+		riff(vp8x(50, 50), vp8(50, 50)),
+		heif(50, 50),
+	} {
+		f.Add(seed)
+	}
+
+	v := testValidator()
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ctype, err := v.Validate(data)
+		if err != nil {
+			var e *Error
+			if !errors.As(err, &e) {
+				t.Fatalf("Validate returned %T, want *Error", err)
+			}
+			return
+		}
+
+		if !v.allowed[ctype] {
+			t.Fatalf("Validate accepted %s", ctype)
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package imagecheck
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+var errJpegTruncated = errors.New("jpeg image is truncated")
+
+// jpegComplete looks for the end of image marker after the first scan.
+// Segments before it are skipped by their length, as an embedded thumbnail
+// has an end marker of its own. Data following the marker is allowed, some
+// cameras append trailers.
+func jpegComplete(data []byte) error {
+	if len(data) < 2 || data[0] != 0xff || data[1] != 0xd8 {
+		return errors.New("not a jpeg image")
+	}
+
+	off := 2
+	for {
+		if off+2 > len(data) {
+			return errJpegTruncated
+		}
+		if data[off] != 0xff {
+			return errors.New("jpeg segment does not start with a marker")
+		}
+
+		marker := data[off+1]
+		switch {
+		case marker == 0xff:
+			// fill byte
+			off++
+			continue
+		case marker == 0xd9:
+			return errors.New("jpeg image has no scan")
+		case marker == 0x01 || marker >= 0xd0 && marker <= 0xd8:
+			// markers without a length
+			off += 2
+			continue
+		}
+
+		if off+4 > len(data) {
+			return errJpegTruncated
+		}
+		n := int(binary.BigEndian.Uint16(data[off+2 : off+4]))
+		if n < 2 {
+			return errors.New("jpeg segment has an invalid length")
+		}
+		off += 2 + n
+
+		// start of scan, entropy coded data follows
+		if marker == 0xda {
+			break
+		}
+	}
+
+	// coded data escapes 0xff, so the first end marker ends the image
+	if off > len(data) || !bytes.Contains(data[off:], []byte{0xff, 0xd9}) {
+		return errJpegTruncated
+	}
+
+	return nil
+}
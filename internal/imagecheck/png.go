@@ -0,0 +1,38 @@
+package imagecheck
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var (
+	errPngTruncated = errors.New("png image is truncated")
+
+	pngSignature = "\x89PNG\r\n\x1a\n"
+)
+
+// pngComplete walks the chunks up to IEND, which ends every PNG.
+func pngComplete(data []byte) error {
+	if len(data) < len(pngSignature) || string(data[:len(pngSignature)]) != pngSignature {
+		return errors.New("not a png image")
+	}
+
+	for off := len(pngSignature); ; {
+		if off+8 > len(data) {
+			return errPngTruncated
+		}
+
+		// length, type, data and crc
+		n := uint64(binary.BigEndian.Uint32(data[off : off+4]))
+		end := uint64(off) + 12 + n
+		if end > uint64(len(data)) {
+			return errPngTruncated
+		}
+
+		if string(data[off+4:off+8]) == "IEND" {
+			return nil
+		}
+
+		off = int(end)
+	}
+}
@@ -0,0 +1,92 @@
+package imagecheck
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var errWebpTruncated = errors.New("webp image is truncated")
+
+// webpChunks walks the chunks of a RIFF WEBP container.
+func webpChunks(data []byte, fn func(fourcc string, payload []byte) bool) error {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return errors.New("not a webp container")
+	}
+
+	size := int(binary.LittleEndian.Uint32(data[4:8]))
+	if size+8 > len(data) {
+		return errWebpTruncated
+	}
+
+	for off := 12; off < size+8; {
+		if off+8 > len(data) {
+			return errWebpTruncated
+		}
+
+		n := int(binary.LittleEndian.Uint32(data[off+4 : off+8]))
+		end := off + 8 + n
+		if end > len(data) {
+			return errWebpTruncated
+		}
+
+		if !fn(string(data[off:off+4]), data[off+8:end]) {
+			return nil
+		}
+
+		// chunks are padded to an even size
+		off = end + n&1
+	}
+
+	return nil
+}
+
+func webpSize(data []byte) (Size, error) {
+	var (
+		size  Size
+		found bool
+	)
+
+	err := webpChunks(data, func(fourcc string, p []byte) bool {
+		switch fourcc {
+		case "VP8X":
+			if len(p) < 10 {
+				return false
+			}
+			size = Size{int(uint24(p[4:7])) + 1, int(uint24(p[7:10])) + 1}
+			found = true
+		case "VP8 ":
+			// frame tag, start code, then 14 bit dimensions
+			if len(p) < 10 || p[3] != 0x9d || p[4] != 0x01 || p[5] != 0x2a {
+				return false
+			}
+			size = Size{int(binary.LittleEndian.Uint16(p[6:8]) & 0x3fff), int(binary.LittleEndian.Uint16(p[8:10]) & 0x3fff)}
+			found = true
+		case "VP8L":
+			if len(p) < 5 || p[0] != 0x2f {
+				return false
+			}
+			bits := binary.LittleEndian.Uint32(p[1:5])
+			size = Size{int(bits&0x3fff) + 1, int(bits>>14&0x3fff) + 1}
+			found = true
+		default:
+			return true
+		}
+		return false
+	})
+	if err != nil {
+		return Size{}, err
+	}
+	if !found {
+		return Size{}, errors.New("webp image has no frame")
+	}
+
+	return size, nil
+}
+
+func webpComplete(data []byte) error {
+	return webpChunks(data, func(string, []byte) bool { return true })
+}
+
+func uint24(b []byte) uint32 {
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16
+}
@@ -0,0 +1,139 @@
+package imagecheck
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+func le32(v uint32) []byte {
+	return binary.LittleEndian.AppendUint32(nil, v)
+}
+
+func chunk(fourcc string, payload []byte) []byte {
+	c := append(append([]byte(fourcc), le32(uint32(len(payload)))...), payload...)
+	if len(payload)%2 == 1 {
+		c = append(c, 0)
+	}
+	return c
+}
+
+func riff(chunks ...[]byte) []byte {
+	body := append([]byte("WEBP"), bytes.Join(chunks, nil)...)
+	return append(append([]byte("RIFF"), le32(uint32(len(body)))...), body...)
+}
+
+func vp8(w, h uint16) []byte {
+	p := []byte{0, 0, 0, 0x9d, 0x01, 0x2a}
+	p = binary.LittleEndian.AppendUint16(p, w)
+	return chunk("VP8 ", binary.LittleEndian.AppendUint16(p, h))
+}
+
+func vp8l(w, h uint32) []byte {
+	return chunk("VP8L", append([]byte{0x2f}, le32((w-1)|(h-1)<<14)...))
+}
+
+func vp8x(w, h uint32) []byte {
+	p := make([]byte, 4)
+	p = append(p, le32(w - 1)[:3]...)
+	return chunk("VP8X", append(p, le32(h - 1)[:3]...))
+}
+
+func TestWebp(t *testing.T) {
+	lossy := riff(vp8(640, 480))
+
+	tests := []struct {
+		name     string
+		data     []byte
+		size     Size
+		sizeErr  error
+		complete error
+	}{
+		{
+			name: "lossy",
+			data: lossy,
+			size: Size{640, 480},
+		},
+		{
+			name: "lossless",
+			data: riff(vp8l(640, 480)),
+			size: Size{640, 480},
+		},
+		{
+			name: "extended",
+			data: riff(vp8x(4000, 3000), chunk("ICCP", make([]byte, 7)), vp8(640, 480)),
+			size: Size{4000, 3000},
+		},
+		{
+			name: "padded chunks are skipped",
+			data: riff(chunk("ICCP", make([]byte, 7)), chunk("EXIF", make([]byte, 3)), vp8l(640, 480)),
+			size: Size{640, 480},
+		},
+		{
+			name:    "no frame",
+			data:    riff(chunk("ICCP", make([]byte, 8))),
+			sizeErr: errors.New("webp image has no frame"),
+		},
+		{
+			name:    "lossy start code",
+			data:    riff(chunk("VP8 ", make([]byte, 10))),
+			sizeErr: errors.New("webp image has no frame"),
+		},
+		{
+			name:    "short lossy header",
+			data:    riff(chunk("VP8 ", []byte{0, 0, 0, 0x9d, 0x01, 0x2a})),
+			sizeErr: errors.New("webp image has no frame"),
+		},
+		{
+			name:    "lossless signature",
+			data:    riff(chunk("VP8L", []byte{0x2e, 0, 0, 0, 0})),
+			sizeErr: errors.New("webp image has no frame"),
+		},
+		{
+			name:    "short extended header",
+			data:    riff(chunk("VP8X", make([]byte, 6))),
+			sizeErr: errors.New("webp image has no frame"),
+		},
+		{
+			name:     "truncated container",
+			data:     lossy[:len(lossy)-1],
+			sizeErr:  errWebpTruncated,
+			complete: errWebpTruncated,
+		},
+		{
+			name:     "chunk beyond the container",
+			data:     riff(append([]byte("ICCP"), le32(64)...)),
+			sizeErr:  errWebpTruncated,
+			complete: errWebpTruncated,
+		},
+		{
+			name:     "truncated chunk header",
+			data:     riff([]byte("ICC")),
+			sizeErr:  errWebpTruncated,
+			complete: errWebpTruncated,
+		},
+		{
+			name:     "not a container",
+			data:     append([]byte("RIFF"), make([]byte, 16)...),
+			sizeErr:  errors.New("not a webp container"),
+			complete: errors.New("not a webp container"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			size, err := webpSize(tt.data)
+			if !sameError(err, tt.sizeErr) {
+				t.Fatalf("webpSize error = %v, want %v", err, tt.sizeErr)
+			}
+			if size != tt.size {
+				t.Fatalf("webpSize = %v, want %v", size, tt.size)
+			}
+
+			if err := webpComplete(tt.data); !sameError(err, tt.complete) {
+				t.Fatalf("webpComplete = %v, want %v", err, tt.complete)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package lockout
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/mzhn-sochi/gateway/internal/config"
+)
+
+const sweepInterval = time.Minute
+
+type entry struct {
+	failures int
+	last     time.Time
+	until    time.Time
+}
+
+// Guard counts failed sign-in attempts per phone number and per client
+// address. Once a key reaches its allowance every further failure locks it
+// for twice as long as the previous one, up to a maximum. Failures are
+// forgotten after a quiet window.
+//
+// An attempt is counted as failed as soon as it begins, so concurrent
+// attempts cannot all pass the check before the first of them fails.
+// Succeeded and Aborted give the attempt back once its outcome is known.
+type Guard struct {
+	config *config.Config
+	audit  *slog.Logger
+
+	mu      sync.Mutex
+	entries map[string]*entry
+	swept   time.Time
+	now     func() time.Time
+}
+
+func New(config *config.Config, logger *slog.Logger) *Guard {
+	return &Guard{
+		config:  config,
+		audit:   logger.With("component", "lockout", "audit", true),
+		entries: make(map[string]*entry),
+		now:     time.Now,
+	}
+}
+
+// Begin reserves a sign-in attempt. When the phone number or client address
+// is locked it returns how long it stays locked and reserves nothing.
+func (g *Guard) Begin(phone, ip string) time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	g.sweep(now)
+
+	var wait time.Duration
+	for _, key := range []string{phoneKey(phone), ipKey(ip)} {
+		if e, ok := g.entries[key]; ok && e.until.After(now) {
+			wait = max(wait, e.until.Sub(now))
+		}
+	}
+	if wait > 0 {
+		return wait
+	}
+
+	c := g.config.Lockout
+	g.fail(now, "phone", phoneKey(phone), c.PhoneAttempts, phone, ip)
+	g.fail(now, "ip", ipKey(ip), c.IPAttempts, phone, ip)
+
+	return 0
+}
+
+// Succeeded clears the failures of the phone number and gives the attempt
+// back to the client address. The address keeps its earlier failures,
+// otherwise signing into an own account between guesses would reset them.
+func (g *Guard) Succeeded(phone, ip string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.entries, phoneKey(phone))
+	g.release(ipKey(ip), g.config.Lockout.IPAttempts)
+}
+
+// Aborted gives the attempt back when the credentials were never checked,
+// e.g. because the auth service did not answer.
+func (g *Guard) Aborted(phone, ip string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	c := g.config.Lockout
+	g.release(phoneKey(phone), c.PhoneAttempts)
+	g.release(ipKey(ip), c.IPAttempts)
+}
+
+func (g *Guard) fail(now time.Time, scope, key string, allowed int, phone, ip string) {
+	// locks longer than the window must not hand out the allowance again
+	e, ok := g.entries[key]
+	if !ok || now.Sub(quietSince(e)) > g.config.Lockout.Window {
+		e = &entry{}
+		g.entries[key] = e
+	}
+
+	e.failures++
+	e.last = now
+
+	over := e.failures - allowed
+	if over < 0 {
+		return
+	}
+
+	d := g.config.Lockout.Base << min(over, 30)
+	if d <= 0 || d > g.config.Lockout.Max {
+		d = g.config.Lockout.Max
+	}
+	e.until = now.Add(d)
+
+	g.audit.Warn("sign-in locked",
+		slog.String("event", "login.lockout"),
+		slog.String("scope", scope),
+		slog.String("phone", mask(phone)),
+		slog.String("ip", ip),
+		slog.Int("failures", e.failures),
+		slog.Duration("duration", d),
+	)
+}
+
+// release uncounts a reserved attempt. A lock the attempt took is lifted only
+// when the key is back within its allowance, a longer one is kept.
+func (g *Guard) release(key string, allowed int) {
+	e, ok := g.entries[key]
+	if !ok || e.failures == 0 {
+		return
+	}
+
+	e.failures--
+	if e.failures < allowed {
+		e.until = time.Time{}
+	}
+}
+
+func (g *Guard) sweep(now time.Time) {
+	if now.Sub(g.swept) < sweepInterval {
+		return
+	}
+	g.swept = now
+
+	for key, e := range g.entries {
+		if now.Sub(quietSince(e)) > g.config.Lockout.Window {
+			delete(g.entries, key)
+		}
+	}
+}
+
+// quietSince returns when the key last failed or its lock ended, whichever
+// is later.
+func quietSince(e *entry) time.Time {
+	if e.until.After(e.last) {
+		return e.until
+	}
+	return e.last
+}
+
+func phoneKey(phone string) string {
+	return "phone:" + phone
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+// mask hides the middle of a phone number in audit logs.
+func mask(phone string) string {
+	if len(phone) < 7 {
+		return "***"
+	}
+	return phone[:4] + "***" + phone[len(phone)-3:]
+}
@@ -0,0 +1,187 @@
+package lockout
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/mzhn-sochi/gateway/internal/config"
+)
+
+// newTestGuard reads the time from now, which tests move forward.
+func newTestGuard(phoneAttempts, ipAttempts int, now *time.Time) *Guard {
+	var cfg config.Config
+	cfg.Lockout.PhoneAttempts = phoneAttempts
+	cfg.Lockout.IPAttempts = ipAttempts
+	cfg.Lockout.Base = time.Second
+	cfg.Lockout.Max = 5 * time.Second
+	cfg.Lockout.Window = time.Minute
+
+	g := New(&cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	g.now = func() time.Time { return *now }
+	return g
+}
+
+const (
+	phone = "79990001122"
+	ip    = "10.0.0.1"
+)
+
+func begin(t *testing.T, g *Guard, phone, ip string) {
+	t.Helper()
+	if wait := g.Begin(phone, ip); wait != 0 {
+		t.Fatalf("Begin(%s, %s) locked for %s", phone, ip, wait)
+	}
+}
+
+func TestGuardBackoff(t *testing.T) {
+	now := time.Unix(1000, 0)
+	g := newTestGuard(2, 100, &now)
+
+	begin(t, g, phone, ip)
+	begin(t, g, phone, ip)
+
+	// every failure past the allowance doubles the lock up to the maximum
+	for _, want := range []time.Duration{
+		time.Second,
+		2 * time.Second,
+		4 * time.Second,
+		5 * time.Second,
+		5 * time.Second,
+	} {
+		if wait := g.Begin(phone, ip); wait != want {
+			t.Fatalf("Begin locked for %s, want %s", wait, want)
+		}
+
+		now = now.Add(want / 2)
+		if wait := g.Begin(phone, ip); wait != want-want/2 {
+			t.Fatalf("Begin locked for %s, want %s", wait, want-want/2)
+		}
+
+		now = now.Add(want - want/2)
+		begin(t, g, phone, ip)
+	}
+}
+
+func TestGuardWindowExpiry(t *testing.T) {
+	now := time.Unix(1000, 0)
+	g := newTestGuard(2, 100, &now)
+
+	begin(t, g, phone, ip)
+	now = now.Add(time.Minute)
+	begin(t, g, phone, ip)
+
+	// the first failure is still within the window of the second one
+	if wait := g.Begin(phone, ip); wait != time.Second {
+		t.Fatalf("Begin locked for %s, want 1s", wait)
+	}
+
+	// the window is counted from the end of the 1s lock
+	now = now.Add(time.Second + time.Minute)
+	begin(t, g, phone, ip)
+	if wait := g.Begin(phone, ip); wait != 2*time.Second {
+		t.Fatalf("Begin locked for %s within the window, want 2s", wait)
+	}
+
+	now = now.Add(2*time.Second + time.Minute + time.Nanosecond)
+	begin(t, g, phone, ip)
+	begin(t, g, phone, ip)
+	if wait := g.Begin(phone, ip); wait != time.Second {
+		t.Fatalf("Begin locked for %s after the window, want 1s", wait)
+	}
+}
+
+func TestGuardReservesAttempts(t *testing.T) {
+	now := time.Unix(1000, 0)
+	g := newTestGuard(2, 100, &now)
+
+	// the outcome of the first attempts is not known yet
+	begin(t, g, phone, ip)
+	begin(t, g, phone, ip)
+
+	if wait := g.Begin(phone, ip); wait == 0 {
+		t.Fatal("attempt past the allowance reserved while others are in flight")
+	}
+}
+
+func TestGuardSucceeded(t *testing.T) {
+	now := time.Unix(1000, 0)
+	g := newTestGuard(2, 2, &now)
+
+	for i := 0; i < 5; i++ {
+		begin(t, g, phone, ip)
+		g.Succeeded(phone, ip)
+	}
+
+	// the address keeps failures made before the success
+	begin(t, g, "79990003344", ip)
+	begin(t, g, phone, ip)
+	g.Succeeded(phone, ip)
+
+	begin(t, g, phone, ip)
+	if wait := g.Begin(phone, ip); wait == 0 {
+		t.Fatal("address not locked after reaching its allowance")
+	}
+}
+
+func TestGuardAborted(t *testing.T) {
+	now := time.Unix(1000, 0)
+	g := newTestGuard(2, 2, &now)
+
+	for i := 0; i < 5; i++ {
+		begin(t, g, phone, ip)
+		g.Aborted(phone, ip)
+	}
+
+	begin(t, g, phone, ip)
+	begin(t, g, phone, ip)
+	g.Aborted(phone, ip)
+
+	// the lock taken by the aborted attempt is lifted
+	begin(t, g, phone, ip)
+}
+
+func TestGuardLocksAddress(t *testing.T) {
+	now := time.Unix(1000, 0)
+	g := newTestGuard(100, 2, &now)
+
+	begin(t, g, "79990000001", ip)
+	begin(t, g, "79990000002", ip)
+
+	if wait := g.Begin("79990000003", ip); wait != time.Second {
+		t.Fatalf("Begin locked for %s, want 1s", wait)
+	}
+	begin(t, g, "79990000003", "10.0.0.2")
+}
+
+func TestGuardLockLongerThanWindow(t *testing.T) {
+	now := time.Unix(1000, 0)
+	g := newTestGuard(2, 100, &now)
+	g.config.Lockout.Max = time.Hour
+
+	begin(t, g, phone, ip)
+	begin(t, g, phone, ip)
+
+	// locks past the window keep doubling instead of starting over
+	for want := time.Second; want < time.Hour; want *= 2 {
+		wait := g.Begin(phone, ip)
+		if wait != want {
+			t.Fatalf("Begin locked for %s, want %s", wait, want)
+		}
+
+		now = now.Add(wait)
+		begin(t, g, phone, ip)
+	}
+
+	if wait := g.Begin(phone, ip); wait != time.Hour {
+		t.Fatalf("Begin locked for %s, want the maximum of 1h", wait)
+	}
+
+	// a sweep after the lock ended must not forget the failures either
+	now = now.Add(time.Hour + sweepInterval)
+	begin(t, g, phone, ip)
+	if wait := g.Begin(phone, ip); wait != time.Hour {
+		t.Fatalf("Begin locked for %s after a sweep, want 1h", wait)
+	}
+}
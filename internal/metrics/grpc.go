@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+func (m *Metrics) UnaryClientInterceptor(upstream string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		m.observeCall(upstream, method, start, err)
+
+		return err
+	}
+}
+
+func (m *Metrics) StreamClientInterceptor(upstream string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			m.observeCall(upstream, method, start, err)
+			return nil, err
+		}
+
+		return &observedStream{
+			ClientStream: stream,
+			serverStream: desc.ServerStreams,
+			done: func(err error) {
+				m.observeCall(upstream, method, start, err)
+			},
+		}, nil
+	}
+}
+
+func (m *Metrics) observeCall(upstream, method string, start time.Time, err error) {
+	code := status.Code(err).String()
+
+	m.grpcRequests.WithLabelValues(upstream, method, code).Inc()
+	m.grpcDuration.WithLabelValues(upstream, method, code).Observe(time.Since(start).Seconds())
+}
+
+// observedStream reports a stream once it is finished: on the first error, on
+// io.EOF for server streams, or after the single reply of a client stream.
+type observedStream struct {
+	grpc.ClientStream
+	serverStream bool
+	done         func(err error)
+	once         sync.Once
+}
+
+func (s *observedStream) SendMsg(msg any) error {
+	err := s.ClientStream.SendMsg(msg)
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.finish(err)
+	}
+	return err
+}
+
+func (s *observedStream) RecvMsg(msg any) error {
+	err := s.ClientStream.RecvMsg(msg)
+
+	switch {
+	case errors.Is(err, io.EOF):
+		s.finish(nil)
+	case err != nil:
+		s.finish(err)
+	case !s.serverStream:
+		s.finish(nil)
+	}
+
+	return err
+}
+
+func (s *observedStream) finish(err error) {
+	s.once.Do(func() {
+		s.done(err)
+	})
+}
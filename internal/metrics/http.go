@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/mzhn-sochi/gateway/internal/apierror"
+)
+
+func (m *Metrics) HTTP(mapping *apierror.Mapping) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		err := c.Next()
+
+		code := c.Response().StatusCode()
+		if err != nil {
+			code = mapping.StatusCode(err)
+		}
+
+		labels := []string{c.Route().Path, utils.CopyString(c.Method()), strconv.Itoa(code)}
+
+		m.httpRequests.WithLabelValues(labels...).Inc()
+		m.httpDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+
+		return err
+	}
+}
@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/mzhn-sochi/gateway/pkg/cache"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "gateway"
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	grpcRequests *prometheus.CounterVec
+	grpcDuration *prometheus.HistogramVec
+
+	uploadBytes  prometheus.Counter
+	uploadChunks prometheus.Counter
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Number of handled HTTP requests.",
+		}, []string{"route", "method", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of handled HTTP requests.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method", "status"}),
+
+		grpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "grpc_client",
+			Name:      "requests_total",
+			Help:      "Number of gRPC calls made to upstream services.",
+		}, []string{"upstream", "method", "code"}),
+		grpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "grpc_client",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of gRPC calls made to upstream services.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"upstream", "method", "code"}),
+
+		uploadBytes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "s3",
+			Name:      "upload_bytes_total",
+			Help:      "Number of bytes streamed to the S3 service.",
+		}),
+		uploadChunks: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "s3",
+			Name:      "upload_chunks_total",
+			Help:      "Number of chunks streamed to the S3 service.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.grpcRequests,
+		m.grpcDuration,
+		m.uploadBytes,
+		m.uploadChunks,
+	)
+
+	return m
+}
+
+func (m *Metrics) Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+}
+
+func (m *Metrics) ObserveUpload(bytes int64, chunks int) {
+	m.uploadBytes.Add(float64(bytes))
+	m.uploadChunks.Add(float64(chunks))
+}
+
+// RegisterCache exposes hit, miss and size statistics of a cache under the given name.
+func (m *Metrics) RegisterCache(name string, stats func() cache.Stats) {
+	labels := prometheus.Labels{"cache": name}
+
+	counter := func(metric, help string, value func(s cache.Stats) uint64) prometheus.Collector {
+		return prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "cache",
+			Name:        metric,
+			Help:        help,
+			ConstLabels: labels,
+		}, func() float64 {
+			return float64(value(stats()))
+		})
+	}
+
+	m.registry.MustRegister(
+		counter("hits_total", "Number of cache hits.", func(s cache.Stats) uint64 { return s.Hits }),
+		counter("misses_total", "Number of cache misses.", func(s cache.Stats) uint64 { return s.Misses }),
+		counter("loads_total", "Number of upstream loads.", func(s cache.Stats) uint64 { return s.Loads }),
+		counter("shared_loads_total", "Number of lookups that joined an in-flight load.", func(s cache.Stats) uint64 { return s.Shared }),
+		counter("evictions_total", "Number of entries evicted by the size bound.", func(s cache.Stats) uint64 { return s.Evictions }),
+		counter("expirations_total", "Number of entries dropped after their TTL.", func(s cache.Stats) uint64 { return s.Expirations }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   "cache",
+			Name:        "size",
+			Help:        "Number of entries in the cache.",
+			ConstLabels: labels,
+		}, func() float64 {
+			return float64(stats().Size)
+		}),
+	)
+}
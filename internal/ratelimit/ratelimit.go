@@ -0,0 +1,133 @@
+package ratelimit
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mzhn-sochi/gateway/internal/apierror"
+	"github.com/mzhn-sochi/gateway/internal/config"
+	"github.com/mzhn-sochi/gateway/internal/entity"
+	"github.com/mzhn-sochi/gateway/pkg/middleware"
+)
+
+const (
+	HeaderLimit     = "RateLimit-Limit"
+	HeaderRemaining = "RateLimit-Remaining"
+	HeaderReset     = "RateLimit-Reset"
+)
+
+// KeyFunc picks the bucket a request is counted against.
+type KeyFunc func(ctx *fiber.Ctx) string
+
+// ByIP counts requests per client address. Behind a proxy the address is a
+// view of the request header, the concatenation copies it.
+func ByIP(ctx *fiber.Ctx) string {
+	return "ip:" + ctx.IP()
+}
+
+// ByUser counts authenticated requests per user and falls back to the
+// client address for anonymous ones.
+func ByUser(ctx *fiber.Ctx) string {
+	if u, ok := ctx.Locals("user").(*entity.UserClaims); ok {
+		return "user:" + u.Id
+	}
+	return ByIP(ctx)
+}
+
+type Limiter struct {
+	store   Store
+	logger  *slog.Logger
+	enabled bool
+
+	ip       Limit
+	auth     Limit
+	upload   Limit
+	standard Limit
+}
+
+func New(config *config.Config, logger *slog.Logger, store Store) (*Limiter, error) {
+	l := &Limiter{
+		store:   store,
+		logger:  logger.With("component", "ratelimit"),
+		enabled: config.RateLimit.Enabled,
+	}
+
+	for _, p := range []struct {
+		limit *Limit
+		value string
+	}{
+		{&l.ip, config.RateLimit.IP},
+		{&l.auth, config.RateLimit.Auth},
+		{&l.upload, config.RateLimit.Upload},
+		{&l.standard, config.RateLimit.Default},
+	} {
+		limit, err := ParseLimit(p.value)
+		if err != nil {
+			return nil, err
+		}
+		*p.limit = limit
+	}
+
+	return l, nil
+}
+
+// IP throttles every request per client address. It runs before the
+// request is authenticated so that a flood never reaches the auth service.
+func (l *Limiter) IP() fiber.Handler {
+	return l.Handler("ip", l.ip, ByIP)
+}
+
+// Auth throttles credential endpoints per client address.
+func (l *Limiter) Auth() fiber.Handler {
+	return l.Handler("auth", l.auth, ByIP)
+}
+
+// Upload throttles endpoints that accept files per user.
+func (l *Limiter) Upload() fiber.Handler {
+	return l.Handler("upload", l.upload, ByUser)
+}
+
+func (l *Limiter) Default() fiber.Handler {
+	return l.Handler("default", l.standard, ByUser)
+}
+
+func (l *Limiter) Handler(name string, limit Limit, key KeyFunc) fiber.Handler {
+	if !l.enabled {
+		return func(ctx *fiber.Ctx) error {
+			return ctx.Next()
+		}
+	}
+
+	return func(ctx *fiber.Ctx) error {
+		res, err := l.store.Take(ctx.Context(), fmt.Sprintf("%s:%s", name, key(ctx)), limit)
+		if err != nil {
+			// a broken store must not take the gateway down with it
+			l.logger.Error("failed to take rate limit token", slog.String("policy", name), slog.String("err", err.Error()))
+			return ctx.Next()
+		}
+
+		ctx.Set(HeaderLimit, strconv.Itoa(limit.Burst))
+		ctx.Set(HeaderRemaining, strconv.Itoa(res.Remaining))
+		ctx.Set(HeaderReset, ceilSeconds(res.Reset))
+
+		if !res.Allowed {
+			if logger, ok := ctx.Locals(middleware.LOGGER).(*slog.Logger); ok {
+				logger.Debug("rate limit exceeded", slog.String("policy", name))
+			}
+
+			e := apierror.New(fiber.StatusTooManyRequests, apierror.CodeTooManyRequests, "rate limit exceeded")
+			e.RetryAfter = res.RetryAfter
+			return e
+		}
+
+		return ctx.Next()
+	}
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
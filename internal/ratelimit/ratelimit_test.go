@@ -0,0 +1,164 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mzhn-sochi/gateway/internal/apierror"
+	"github.com/mzhn-sochi/gateway/internal/config"
+	"github.com/mzhn-sochi/gateway/internal/entity"
+)
+
+type fakeStore struct {
+	res  Result
+	err  error
+	keys []string
+}
+
+func (s *fakeStore) Take(_ context.Context, key string, _ Limit) (Result, error) {
+	s.keys = append(s.keys, key)
+	return s.res, s.err
+}
+
+func newTestLimiter(t *testing.T, enabled bool, store Store) *Limiter {
+	t.Helper()
+
+	var cfg config.Config
+	cfg.RateLimit.Enabled = enabled
+	cfg.RateLimit.IP = "100/1m"
+	cfg.RateLimit.Auth = "10/1m"
+	cfg.RateLimit.Upload = "20/1m"
+	cfg.RateLimit.Default = "300/1m"
+
+	l, err := New(&cfg, slog.New(slog.NewTextHandler(io.Discard, nil)), store)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	return l
+}
+
+func serve(t *testing.T, handler fiber.Handler, user *entity.UserClaims) *http.Response {
+	t.Helper()
+
+	app := fiber.New(fiber.Config{ErrorHandler: apierror.Handler(slog.New(slog.NewTextHandler(io.Discard, nil)), apierror.NewMapping())})
+	app.Get("/", func(ctx *fiber.Ctx) error {
+		if user != nil {
+			ctx.Locals("user", user)
+		}
+		return ctx.Next()
+	}, handler, func(ctx *fiber.Ctx) error {
+		return ctx.SendStatus(fiber.StatusNoContent)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	return resp
+}
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name   string
+		res    Result
+		err    error
+		status int
+		header map[string]string
+	}{
+		{
+			name:   "allowed",
+			res:    Result{Allowed: true, Remaining: 7, Reset: 1500 * time.Millisecond},
+			status: fiber.StatusNoContent,
+			header: map[string]string{
+				HeaderLimit:     "300",
+				HeaderRemaining: "7",
+				HeaderReset:     "2",
+			},
+		},
+		{
+			name:   "rejected",
+			res:    Result{Remaining: 0, Reset: time.Minute, RetryAfter: 200 * time.Millisecond},
+			status: fiber.StatusTooManyRequests,
+			header: map[string]string{
+				HeaderLimit:            "300",
+				HeaderRemaining:        "0",
+				HeaderReset:            "60",
+				fiber.HeaderRetryAfter: "1",
+			},
+		},
+		{
+			name:   "store failure passes the request",
+			err:    errors.New("store down"),
+			status: fiber.StatusNoContent,
+			header: map[string]string{
+				HeaderLimit: "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeStore{res: tt.res, err: tt.err}
+			resp := serve(t, newTestLimiter(t, true, store).Default(), nil)
+
+			if resp.StatusCode != tt.status {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+			for k, want := range tt.header {
+				if got := resp.Header.Get(k); got != want {
+					t.Errorf("%s = %q, want %q", k, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerKeys(t *testing.T) {
+	user := &entity.UserClaims{Id: "42"}
+
+	tests := []struct {
+		name    string
+		handler func(*Limiter) fiber.Handler
+		user    *entity.UserClaims
+		key     string
+	}{
+		{"ip", (*Limiter).IP, user, "ip:ip:0.0.0.0"},
+		{"auth", (*Limiter).Auth, user, "auth:ip:0.0.0.0"},
+		{"upload by user", (*Limiter).Upload, user, "upload:user:42"},
+		{"default by user", (*Limiter).Default, user, "default:user:42"},
+		{"default anonymous", (*Limiter).Default, nil, "default:ip:0.0.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeStore{res: Result{Allowed: true}}
+			serve(t, tt.handler(newTestLimiter(t, true, store)), tt.user)
+
+			if len(store.keys) != 1 || store.keys[0] != tt.key {
+				t.Fatalf("keys = %q, want [%q]", store.keys, tt.key)
+			}
+		})
+	}
+}
+
+func TestHandlerDisabled(t *testing.T) {
+	store := &fakeStore{}
+	resp := serve(t, newTestLimiter(t, false, store).Default(), nil)
+
+	if resp.StatusCode != fiber.StatusNoContent {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusNoContent)
+	}
+	if len(store.keys) != 0 {
+		t.Fatalf("disabled limiter took tokens for %q", store.keys)
+	}
+	if got := resp.Header.Get(HeaderLimit); got != "" {
+		t.Fatalf("%s = %q on a disabled limiter", HeaderLimit, got)
+	}
+}
@@ -0,0 +1,98 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// newTestStore reads the time from now, which tests move forward.
+func newTestStore(now *time.Time) *MemoryStore {
+	s := NewMemoryStore()
+	s.now = func() time.Time { return *now }
+	return s
+}
+
+func take(t *testing.T, s *MemoryStore, key string, limit Limit) Result {
+	t.Helper()
+	res, err := s.Take(context.Background(), key, limit)
+	if err != nil {
+		t.Fatalf("Take(%q) failed: %v", key, err)
+	}
+	return res
+}
+
+func TestMemoryStoreTokenBucket(t *testing.T) {
+	now := time.Unix(1000, 0)
+	s := newTestStore(&now)
+	limit := Limit{Burst: 3, Period: 3 * time.Second}
+
+	for i, want := range []Result{
+		{Allowed: true, Remaining: 2, Reset: time.Second},
+		{Allowed: true, Remaining: 1, Reset: 2 * time.Second},
+		{Allowed: true, Remaining: 0, Reset: 3 * time.Second},
+		{Allowed: false, Remaining: 0, Reset: 3 * time.Second, RetryAfter: time.Second},
+	} {
+		if got := take(t, s, "k", limit); got != want {
+			t.Fatalf("take %d = %+v, want %+v", i, got, want)
+		}
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if got := take(t, s, "k", limit); got.Allowed || got.RetryAfter != 500*time.Millisecond {
+		t.Fatalf("half refilled take = %+v, want rejected with 500ms retry", got)
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if got := take(t, s, "k", limit); !got.Allowed || got.Remaining != 0 {
+		t.Fatalf("refilled take = %+v, want allowed with nothing remaining", got)
+	}
+
+	now = now.Add(time.Hour)
+	if got := take(t, s, "k", limit); !got.Allowed || got.Remaining != 2 {
+		t.Fatalf("idle take = %+v, want allowed with 2 remaining, the bucket must not overfill", got)
+	}
+}
+
+func TestMemoryStoreKeysAreIndependent(t *testing.T) {
+	now := time.Unix(1000, 0)
+	s := newTestStore(&now)
+	limit := Limit{Burst: 1, Period: time.Minute}
+
+	if !take(t, s, "a", limit).Allowed {
+		t.Fatal("first take of a rejected")
+	}
+	if take(t, s, "a", limit).Allowed {
+		t.Fatal("second take of a allowed")
+	}
+	if !take(t, s, "b", limit).Allowed {
+		t.Fatal("b rejected because a is drained")
+	}
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	now := time.Unix(1000, 0)
+	s := newTestStore(&now)
+
+	take(t, s, "short", Limit{Burst: 1, Period: time.Second})
+	take(t, s, "long", Limit{Burst: 1, Period: time.Hour})
+
+	// idle buckets are only dropped once per sweep interval
+	now = now.Add(2 * time.Second)
+	take(t, s, "other", Limit{Burst: 1, Period: time.Second})
+	if _, ok := s.buckets["short"]; !ok {
+		t.Fatal("bucket swept before the sweep interval passed")
+	}
+
+	now = now.Add(sweepInterval)
+	take(t, s, "other", Limit{Burst: 1, Period: time.Second})
+	if _, ok := s.buckets["short"]; ok {
+		t.Fatal("refilled bucket was not swept")
+	}
+	if _, ok := s.buckets["long"]; !ok {
+		t.Fatal("bucket swept before it refilled")
+	}
+	if _, ok := s.buckets["other"]; !ok {
+		t.Fatal("bucket in use was swept")
+	}
+}
@@ -0,0 +1,123 @@
+package resilience
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	StateClosed   = "closed"
+	StateOpen     = "open"
+	StateHalfOpen = "half-open"
+)
+
+// Breaker opens after a number of consecutive failures and rejects calls
+// until the cooldown passes. Then a single probe call decides whether it
+// closes again. A probe whose outcome never arrives, e.g. a stream that is
+// not closed, is given up after another cooldown.
+type Breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+
+	state    string
+	failures int
+	openedAt time.Time
+	probing  bool
+	probedAt time.Time
+
+	now func() time.Time
+}
+
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		state:     StateClosed,
+		now:       time.Now,
+	}
+}
+
+// Allow reports whether a call may proceed and, if not, how long until the next probe.
+func (b *Breaker) Allow() (bool, time.Duration) {
+	if b.threshold <= 0 {
+		return true, 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+
+	switch b.state {
+	case StateOpen:
+		wait := b.cooldown - now.Sub(b.openedAt)
+		if wait > 0 {
+			return false, wait
+		}
+		b.state = StateHalfOpen
+		b.probe(now)
+		return true, 0
+	case StateHalfOpen:
+		if b.probing {
+			if wait := b.cooldown - now.Sub(b.probedAt); wait > 0 {
+				return false, wait
+			}
+		}
+		b.probe(now)
+		return true, 0
+	}
+
+	return true, 0
+}
+
+func (b *Breaker) probe(now time.Time) {
+	b.probing = true
+	b.probedAt = now
+}
+
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = StateClosed
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *Breaker) Failure() {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+
+	if b.state == StateHalfOpen || b.failures >= b.threshold {
+		b.state = StateOpen
+		b.openedAt = b.now()
+	}
+}
+
+// Release ends a call whose outcome says nothing about the upstream, such as
+// one canceled by the client, so that a pending probe can be retried.
+func (b *Breaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+func (b *Breaker) State() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.cooldown {
+		return StateHalfOpen
+	}
+
+	return b.state
+}
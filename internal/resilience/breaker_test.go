@@ -0,0 +1,119 @@
+package resilience
+
+import (
+	"testing"
+	"time"
+)
+
+// newTestBreaker reads the time from now, which tests move forward.
+func newTestBreaker(threshold int, cooldown time.Duration, now *time.Time) *Breaker {
+	b := NewBreaker(threshold, cooldown)
+	b.now = func() time.Time { return *now }
+	return b
+}
+
+func trip(t *testing.T, b *Breaker, threshold int) {
+	t.Helper()
+	for i := 0; i < threshold; i++ {
+		if ok, _ := b.Allow(); !ok {
+			t.Fatalf("call %d rejected before the breaker opened", i)
+		}
+		b.Failure()
+	}
+	if b.State() != StateOpen {
+		t.Fatalf("state = %s, want %s", b.State(), StateOpen)
+	}
+}
+
+func TestBreakerOpensAndRecovers(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := newTestBreaker(3, time.Second, &now)
+	trip(t, b, 3)
+
+	if ok, wait := b.Allow(); ok || wait != time.Second {
+		t.Fatalf("Allow = %v, %s, want false, 1s", ok, wait)
+	}
+
+	now = now.Add(time.Second)
+	if ok, _ := b.Allow(); !ok {
+		t.Fatal("probe rejected after the cooldown")
+	}
+	if ok, _ := b.Allow(); ok {
+		t.Fatal("second call allowed while probing")
+	}
+
+	b.Success()
+	if b.State() != StateClosed {
+		t.Fatalf("state = %s, want %s", b.State(), StateClosed)
+	}
+	if ok, _ := b.Allow(); !ok {
+		t.Fatal("call rejected by a closed breaker")
+	}
+}
+
+func TestBreakerFailedProbeReopens(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := newTestBreaker(1, time.Second, &now)
+	trip(t, b, 1)
+
+	now = now.Add(time.Second)
+	if ok, _ := b.Allow(); !ok {
+		t.Fatal("probe rejected after the cooldown")
+	}
+	b.Failure()
+
+	if ok, _ := b.Allow(); ok {
+		t.Fatal("call allowed right after a failed probe")
+	}
+}
+
+func TestBreakerReleasedProbe(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := newTestBreaker(1, time.Second, &now)
+	trip(t, b, 1)
+
+	now = now.Add(time.Second)
+	if ok, _ := b.Allow(); !ok {
+		t.Fatal("probe rejected after the cooldown")
+	}
+
+	// the probe was canceled by its client
+	b.Release()
+
+	if ok, _ := b.Allow(); !ok {
+		t.Fatal("no new probe allowed after the previous one was released")
+	}
+}
+
+func TestBreakerAbandonedProbeExpires(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := newTestBreaker(1, time.Second, &now)
+	trip(t, b, 1)
+
+	now = now.Add(time.Second)
+	if ok, _ := b.Allow(); !ok {
+		t.Fatal("probe rejected after the cooldown")
+	}
+
+	// the outcome of the probe never arrives
+	now = now.Add(time.Second / 2)
+	if ok, wait := b.Allow(); ok || wait != time.Second/2 {
+		t.Fatalf("Allow = %v, %s, want false, 500ms", ok, wait)
+	}
+
+	now = now.Add(time.Second / 2)
+	if ok, _ := b.Allow(); !ok {
+		t.Fatal("abandoned probe still blocks the upstream")
+	}
+}
+
+func TestBreakerDisabled(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := newTestBreaker(0, time.Second, &now)
+	for i := 0; i < 10; i++ {
+		b.Failure()
+	}
+	if ok, _ := b.Allow(); !ok {
+		t.Fatal("disabled breaker rejected a call")
+	}
+}
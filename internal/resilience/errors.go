@@ -0,0 +1,27 @@
+package resilience
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// OpenError is returned instead of calling an upstream whose breaker is open.
+type OpenError struct {
+	Upstream string
+	Wait     time.Duration
+}
+
+func (e *OpenError) Error() string {
+	return fmt.Sprintf("%s is unavailable, retry in %s", e.Upstream, e.Wait.Round(time.Second))
+}
+
+func (e *OpenError) RetryAfter() time.Duration {
+	return e.Wait
+}
+
+func (e *OpenError) GRPCStatus() *status.Status {
+	return status.New(codes.Unavailable, e.Error())
+}
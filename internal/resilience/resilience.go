@@ -0,0 +1,230 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mzhn-sochi/gateway/internal/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const healthService = "/grpc.health.v1.Health/"
+
+// idempotent lists the read-only RPCs that are safe to retry.
+var idempotent = map[string]bool{
+	"/auth.Auth/FindUserById":               true,
+	"/auth.Auth/FindUsersByIds":             true,
+	"/auth.Auth/FindUserByPhone":            true,
+	"/ticket.TicketService/FindById":        true,
+	"/ticket.TicketService/List":            true,
+	"/ShopSuggestionsService/GetSuggestion": true,
+}
+
+type Resilience struct {
+	mu       sync.Mutex
+	breakers map[string]*Breaker
+}
+
+func New() *Resilience {
+	return &Resilience{
+		breakers: make(map[string]*Breaker),
+	}
+}
+
+// BreakerState returns the breaker state of the upstream, or an empty string
+// for upstreams without a breaker.
+func (r *Resilience) BreakerState(upstream string) string {
+	r.mu.Lock()
+	b, ok := r.breakers[upstream]
+	r.mu.Unlock()
+
+	if !ok {
+		return ""
+	}
+
+	return b.State()
+}
+
+// DialOptions applies the timeout, retry and circuit breaker policy of the upstream to its connection.
+func (r *Resilience) DialOptions(upstream string, policy config.Resilience) []grpc.DialOption {
+	b := NewBreaker(policy.BreakerFailures, policy.BreakerCooldown)
+
+	r.mu.Lock()
+	r.breakers[upstream] = b
+	r.mu.Unlock()
+
+	p := &upstreamPolicy{
+		name:    upstream,
+		policy:  policy,
+		breaker: b,
+	}
+
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(p.unary),
+		grpc.WithChainStreamInterceptor(p.stream),
+	}
+}
+
+type upstreamPolicy struct {
+	name    string
+	policy  config.Resilience
+	breaker *Breaker
+}
+
+func (p *upstreamPolicy) unary(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	// health checks report on the upstream themselves and must not trip the breaker
+	if strings.HasPrefix(method, healthService) {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	attempts := 1
+	if idempotent[method] {
+		attempts += p.policy.Retries
+	}
+
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if werr := p.wait(ctx, attempt); werr != nil {
+				return err
+			}
+		}
+
+		if allowed, wait := p.breaker.Allow(); !allowed {
+			return &OpenError{Upstream: p.name, Wait: wait}
+		}
+
+		err = p.invoke(ctx, method, req, reply, cc, invoker, opts...)
+		p.record(ctx, err)
+
+		if !retryable(err) || ctx.Err() != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+func (p *upstreamPolicy) invoke(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if p.policy.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.policy.Timeout)
+		defer cancel()
+	}
+
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+func (p *upstreamPolicy) stream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if allowed, wait := p.breaker.Allow(); !allowed {
+		return nil, &OpenError{Upstream: p.name, Wait: wait}
+	}
+
+	// streams such as uploads last as long as the caller needs, so they run
+	// under the caller's deadline rather than the per-call timeout
+	s, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		p.record(ctx, err)
+		return nil, err
+	}
+
+	return &guardedStream{
+		ClientStream: s,
+		serverStream: desc.ServerStreams,
+		done: func(err error) {
+			p.record(ctx, err)
+		},
+	}, nil
+}
+
+// record feeds the outcome of a call to the breaker. Only errors that point
+// at an unreachable or overloaded upstream count as failures, application
+// errors such as Internal or Unknown do not; calls canceled by the client are
+// ignored but still release a pending probe.
+func (p *upstreamPolicy) record(ctx context.Context, err error) {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		if errors.Is(ctx.Err(), context.Canceled) {
+			p.breaker.Release()
+			return
+		}
+		p.breaker.Failure()
+	case codes.Canceled:
+		p.breaker.Release()
+	default:
+		p.breaker.Success()
+	}
+}
+
+// wait sleeps for a jittered exponential backoff before the given attempt.
+func (p *upstreamPolicy) wait(ctx context.Context, attempt int) error {
+	backoff := p.policy.BackoffBase << (attempt - 1)
+	if backoff <= 0 || backoff > p.policy.BackoffMax {
+		backoff = p.policy.BackoffMax
+	}
+
+	d := time.Duration(rand.Int63n(int64(backoff) + 1))
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		var open *OpenError
+		return !errors.As(err, &open)
+	}
+	return false
+}
+
+// guardedStream reports the outcome of a stream once it is finished.
+type guardedStream struct {
+	grpc.ClientStream
+	serverStream bool
+	done         func(err error)
+	once         sync.Once
+}
+
+func (s *guardedStream) SendMsg(msg any) error {
+	err := s.ClientStream.SendMsg(msg)
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.finish(err)
+	}
+	return err
+}
+
+func (s *guardedStream) RecvMsg(msg any) error {
+	err := s.ClientStream.RecvMsg(msg)
+
+	switch {
+	case errors.Is(err, io.EOF):
+		s.finish(nil)
+	case err != nil:
+		s.finish(err)
+	case !s.serverStream:
+		s.finish(nil)
+	}
+
+	return err
+}
+
+func (s *guardedStream) finish(err error) {
+	s.once.Do(func() {
+		s.done(err)
+	})
+}
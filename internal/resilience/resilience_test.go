@@ -0,0 +1,61 @@
+package resilience
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mzhn-sochi/gateway/internal/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRecordCountsUpstreamFailures(t *testing.T) {
+	tests := []struct {
+		code codes.Code
+		open bool
+	}{
+		{codes.Unavailable, true},
+		{codes.DeadlineExceeded, true},
+		{codes.ResourceExhausted, true},
+		{codes.Internal, false},
+		{codes.Unknown, false},
+		{codes.NotFound, false},
+		{codes.Canceled, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			p := &upstreamPolicy{name: "test", breaker: NewBreaker(1, time.Second)}
+
+			p.record(context.Background(), status.Error(tt.code, "boom"))
+
+			if open := p.breaker.State() == StateOpen; open != tt.open {
+				t.Fatalf("breaker open = %v, want %v", open, tt.open)
+			}
+		})
+	}
+}
+
+func TestStreamKeepsCallerDeadline(t *testing.T) {
+	p := &upstreamPolicy{
+		name:    "test",
+		policy:  config.Resilience{Timeout: time.Second},
+		breaker: NewBreaker(1, time.Second),
+	}
+
+	var hasDeadline bool
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		_, hasDeadline = ctx.Deadline()
+		return nil, status.Error(codes.Internal, "boom")
+	}
+
+	if _, err := p.stream(context.Background(), &grpc.StreamDesc{}, nil, "/s3.S3/Upload", streamer); err == nil {
+		t.Fatal("stream succeeded, want the streamer error")
+	}
+
+	if hasDeadline {
+		t.Fatal("stream got the per-call timeout, want the caller's deadline")
+	}
+}
@@ -0,0 +1,7 @@
+package analyzerservice
+
+import "errors"
+
+var (
+	ErrInvalidImage = errors.New("invalid image")
+)
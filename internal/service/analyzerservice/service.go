@@ -0,0 +1,119 @@
+package analyzerservice
+
+import (
+	"context"
+	"errors"
+	"github.com/mzhn-sochi/gateway/api/pricetaganalyzer"
+	"github.com/mzhn-sochi/gateway/internal/config"
+	"github.com/mzhn-sochi/gateway/internal/entity"
+	"github.com/mzhn-sochi/gateway/internal/grpcconn"
+	"github.com/mzhn-sochi/gateway/pkg/file"
+	"github.com/mzhn-sochi/gateway/pkg/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"log/slog"
+)
+
+const chunkSize = 1 << 20
+
+type Service struct {
+	config *config.Config
+	client pricetaganalyzer.PriceTagAnalyzerServiceClient
+	conn   *grpc.ClientConn
+}
+
+func New(config *config.Config, conns *grpcconn.Manager) (*Service, error) {
+	c := config.Services.PriceTagAnalyzer
+	conn, err := conns.Dial(grpcconn.Target{
+		Name:       "analyzer",
+		Host:       c.Host,
+		Port:       c.Port,
+		Resilience: c.Resilience,
+		TLS:        c.TLS,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	client := pricetaganalyzer.NewPriceTagAnalyzerServiceClient(conn)
+
+	return &Service{
+		config: config,
+		client: client,
+		conn:   conn,
+	}, nil
+}
+
+func (s *Service) Analyze(ctx context.Context, reader file.Reader) (*entity.ImageInfo, error) {
+
+	l := ctx.Value(middleware.LOGGER).(*slog.Logger).With("service", "analyzer").With("method", "Analyze")
+
+	stream, err := s.client.AnalyzeImage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	l.Debug(
+		"send image",
+		slog.String("contentType", reader.ContentType()),
+		slog.Int64("size", reader.Size()),
+	)
+
+	chunk := make([]byte, chunkSize)
+	for i := 0; ; i++ {
+		read, err := reader.Read(chunk)
+		if read > 0 {
+			l.Debug("sending image chunk", slog.Int("chunk", i), slog.Int("read", read))
+			if err := stream.Send(&pricetaganalyzer.ImageChunk{Content: chunk[:read]}); err != nil {
+				return nil, err
+			}
+		}
+
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+	}
+
+	reply, err := stream.CloseAndRecv()
+	if err != nil {
+		if e, ok := status.FromError(err); ok {
+			if e.Code() == codes.InvalidArgument {
+				l.Error("grpc error", slog.String("err", e.String()))
+				return nil, ErrInvalidImage
+			}
+		}
+
+		return nil, err
+	}
+
+	l.Debug("received reply", slog.String("reply", reply.String()))
+
+	var measure *entity.Measure
+	if reply.Measure != nil {
+		measure = &entity.Measure{
+			Amount: reply.Measure.Amount,
+			Unit:   reply.Measure.Unit,
+		}
+	}
+
+	return &entity.ImageInfo{
+		Product:     reply.Product,
+		Description: reply.Description,
+		Price:       reply.Price,
+		Measure:     measure,
+		Attributes:  reply.Attributes,
+	}, nil
+}
+
+func (s *Service) Name() string {
+	return "analyzer"
+}
+
+func (s *Service) Conn() *grpc.ClientConn {
+	return s.conn
+}
@@ -2,15 +2,18 @@ package authservice
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/mzhn-sochi/gateway/api/auth"
 	"github.com/mzhn-sochi/gateway/internal/config"
 	"github.com/mzhn-sochi/gateway/internal/entity"
 	"github.com/mzhn-sochi/gateway/internal/entity/dto"
+	"github.com/mzhn-sochi/gateway/internal/grpcconn"
+	"github.com/mzhn-sochi/gateway/internal/metrics"
+	"github.com/mzhn-sochi/gateway/pkg/cache"
 	"github.com/mzhn-sochi/gateway/pkg/middleware"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
 	"log/slog"
 )
@@ -18,34 +21,62 @@ import (
 type Service struct {
 	config *config.Config
 	client auth.AuthClient
+	conn   *grpc.ClientConn
+	users  *cache.Cache[string, entity.User]
+
+	verifier *verifier
+	checked  *cache.Cache[string, struct{}]
 }
 
 //var _ controllers.AuthService = (*Service)(nil)
 
-func New(config *config.Config, logger *slog.Logger) *Service {
+func New(config *config.Config, logger *slog.Logger, metrics *metrics.Metrics, conns *grpcconn.Manager) (*Service, error) {
 
 	l := logger.With("service", "auth")
 
-	host := config.Services.AuthService.Host
-	port := config.Services.AuthService.Port
-
-	l.Info("connecting to grpc service", slog.String("host", host), slog.Int("port", port))
+	c := config.Services.AuthService
+	conn, err := conns.Dial(grpcconn.Target{
+		Name:       "auth",
+		Host:       c.Host,
+		Port:       c.Port,
+		Resilience: c.Resilience,
+		TLS:        c.TLS,
 
-	conn, err := grpc.NewClient(
-		fmt.Sprintf("%s:%d", host, port),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+		ForwardAuthorization: true,
+	})
 	if err != nil {
-		l.Error("error with connection to grpc service", slog.String("err", err.Error()))
-		panic(err)
+		return nil, err
 	}
 
 	client := auth.NewAuthClient(conn)
 
-	return &Service{
+	s := &Service{
 		config: config,
 		client: client,
+		conn:   conn,
+		users:  cache.New[string, entity.User](config.UserCache.Size, config.UserCache.TTL),
 	}
+
+	s.users.SetLoadTimeout(config.UserCache.LoadTimeout)
+	metrics.RegisterCache("users", s.users.Stats)
+
+	switch config.Auth.Mode {
+	case "remote":
+	case "local":
+		v, err := newVerifier(config, l)
+		if err != nil {
+			return nil, fmt.Errorf("local token verification: %w", err)
+		}
+
+		s.verifier = v
+		s.checked = cache.New[string, struct{}](config.Auth.RevocationCacheSize, config.Auth.RevocationCheckInterval)
+
+		l.Info("verifying access tokens locally", slog.Duration("revocationCheckInterval", config.Auth.RevocationCheckInterval))
+	default:
+		return nil, fmt.Errorf("unknown auth mode %q", config.Auth.Mode)
+	}
+
+	return s, nil
 }
 
 func (s *Service) SignIn(ctx context.Context, credentials *entity.UserCredentials) (*entity.Tokens, error) {
@@ -127,6 +158,58 @@ func (s *Service) Refresh(ctx context.Context, refreshToken string) (*entity.Tok
 }
 
 func (s *Service) Authenticate(ctx context.Context, accessToken string, role auth.Role) (*entity.UserClaims, error) {
+	if s.verifier == nil {
+		return s.authenticate(ctx, accessToken, role)
+	}
+
+	logger := ctx.Value(middleware.LOGGER).(*slog.Logger).With("service", "auth").With("method", "Auth")
+
+	claims, err := s.verifier.verify(accessToken)
+	if err != nil {
+		logger.Debug("error with local auth", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	if !permitted(claims.Role, role) {
+		return nil, ErrForbidden
+	}
+
+	if err := s.checkRevocation(ctx, accessToken, auth.Role(claims.Role)); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// checkRevocation asks the auth service whether the token is still valid at most
+// once per revocation check interval. Failures other than a rejected token are
+// logged and ignored so that the gateway keeps serving while the auth service is down.
+func (s *Service) checkRevocation(ctx context.Context, accessToken string, role auth.Role) error {
+	if s.config.Auth.RevocationCheckInterval <= 0 {
+		return nil
+	}
+
+	if _, ok := s.checked.Get(accessToken); ok {
+		return nil
+	}
+
+	logger := ctx.Value(middleware.LOGGER).(*slog.Logger).With("service", "auth").With("method", "checkRevocation")
+
+	if _, err := s.authenticate(ctx, accessToken, role); err != nil {
+		if errors.Is(err, ErrUnauthorized) || errors.Is(err, ErrNotFound) {
+			return ErrUnauthorized
+		}
+
+		logger.Warn("revocation check failed, trusting local verification", slog.String("err", err.Error()))
+		return nil
+	}
+
+	s.checked.Set(accessToken, struct{}{})
+
+	return nil
+}
+
+func (s *Service) authenticate(ctx context.Context, accessToken string, role auth.Role) (*entity.UserClaims, error) {
 	logger := ctx.Value(middleware.LOGGER).(*slog.Logger).With("service", "auth").With("method", "Auth")
 
 	req := &auth.AuthRequest{
@@ -171,6 +254,17 @@ func (s *Service) Authenticate(ctx context.Context, accessToken string, role aut
 }
 
 func (s *Service) FindById(ctx context.Context, id string) (*entity.User, error) {
+	user, err := s.users.GetOrLoad(ctx, id, func(ctx context.Context) (entity.User, error) {
+		return s.findById(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (s *Service) findById(ctx context.Context, id string) (entity.User, error) {
 	logger := ctx.Value(middleware.LOGGER).(*slog.Logger).With("service", "auth").With("method", "FindUserById")
 	logger.Debug("trying to find user by id", slog.String("id", id))
 
@@ -181,13 +275,99 @@ func (s *Service) FindById(ctx context.Context, id string) (*entity.User, error)
 	response, err := s.client.FindUserById(ctx, req)
 	if err != nil {
 		logger.Debug("error with find user by id", slog.String("err", err.Error()))
-		return nil, err
+		return entity.User{}, err
 	}
 
-	return &entity.User{
+	return entity.User{
 		Phone:      response.Phone,
 		LastName:   response.LastName,
 		FirstName:  response.FirstName,
 		MiddleName: response.MiddleName,
 	}, nil
 }
+
+func (s *Service) FindIdByPhone(ctx context.Context, phone string) (string, error) {
+	logger := ctx.Value(middleware.LOGGER).(*slog.Logger).With("service", "auth").With("method", "FindUserByPhone")
+	logger.Debug("trying to find user by phone", slog.String("phone", phone))
+
+	req := &auth.FindUserByPhoneRequest{
+		Phone: phone,
+	}
+
+	response, err := s.client.FindUserByPhone(ctx, req)
+	if err != nil {
+		if e, ok := status.FromError(err); ok && e.Code() == codes.NotFound {
+			return "", ErrNotFound
+		}
+
+		logger.Debug("error with find user by phone", slog.String("err", err.Error()))
+		return "", err
+	}
+
+	return response.Id, nil
+}
+
+func (s *Service) FindByIds(ctx context.Context, ids []string) (map[string]*entity.User, error) {
+	logger := ctx.Value(middleware.LOGGER).(*slog.Logger).With("service", "auth").With("method", "FindUsersByIds")
+
+	users := make(map[string]*entity.User, len(ids))
+	missing := make([]string, 0, len(ids))
+
+	for _, id := range ids {
+		if u, ok := s.users.Get(id); ok {
+			users[id] = &u
+			continue
+		}
+		missing = append(missing, id)
+	}
+
+	logger.Debug("trying to find users by ids", slog.Int("cached", len(users)), slog.Int("missing", len(missing)))
+
+	if len(missing) == 0 {
+		return users, nil
+	}
+
+	req := &auth.FindUsersByIdsRequest{
+		Ids: missing,
+	}
+
+	response, err := s.client.FindUsersByIds(ctx, req)
+	if err != nil {
+		logger.Debug("error with find users by ids", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	for _, u := range response.Users {
+		user := entity.User{
+			Phone:      u.Phone,
+			LastName:   u.LastName,
+			FirstName:  u.FirstName,
+			MiddleName: u.MiddleName,
+		}
+
+		s.users.Set(u.Id, user)
+		users[u.Id] = &user
+	}
+
+	return users, nil
+}
+
+func (s *Service) InvalidateUsers(ids ...string) {
+	s.users.Delete(ids...)
+}
+
+func (s *Service) PurgeUsers() {
+	s.users.Purge()
+}
+
+func (s *Service) UserCacheStats() cache.Stats {
+	return s.users.Stats()
+}
+
+func (s *Service) Name() string {
+	return "auth"
+}
+
+func (s *Service) Conn() *grpc.ClientConn {
+	return s.conn
+}
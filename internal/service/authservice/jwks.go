@@ -0,0 +1,244 @@
+package authservice
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minFetchInterval limits how often an unknown key id triggers a fetch.
+const minFetchInterval = 30 * time.Second
+
+// jwks holds public keys from a JWKS document, which is either a local file or an http(s) URL.
+// The whole set is replaced every refresh interval, so removed keys stop being trusted.
+type jwks struct {
+	source  string
+	refresh time.Duration
+	logger  *slog.Logger
+
+	mu        sync.RWMutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+
+	// fetch serializes loads, callers missing a key wait for the one in flight
+	fetch sync.Mutex
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+}
+
+func newJWKS(source string, refresh time.Duration, logger *slog.Logger) (*jwks, error) {
+	set := &jwks{
+		source:  source,
+		refresh: refresh,
+		logger:  logger.With("component", "jwks"),
+	}
+
+	if err := set.load(); err != nil {
+		return nil, err
+	}
+
+	go set.run()
+
+	return set, nil
+}
+
+// run refreshes the key set for the lifetime of the process. A failed
+// refresh keeps the previous keys.
+func (s *jwks) run() {
+	ticker := time.NewTicker(s.refresh)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.fetch.Lock()
+		err := s.load()
+		s.fetch.Unlock()
+
+		if err != nil {
+			s.logger.Warn("failed to refresh jwks", slog.String("err", err.Error()))
+		}
+	}
+}
+
+func (s *jwks) key(kid string) (crypto.PublicKey, error) {
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+
+	// a new key may have been published before the next refresh
+	s.fetch.Lock()
+	defer s.fetch.Unlock()
+
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+
+	s.mu.RLock()
+	recent := time.Since(s.fetchedAt) < minFetchInterval
+	s.mu.RUnlock()
+
+	if !recent {
+		if err := s.load(); err != nil {
+			return nil, err
+		}
+
+		if key, ok := s.lookup(kid); ok {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown key id %q", kid)
+}
+
+func (s *jwks) lookup(kid string) (crypto.PublicKey, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+func (s *jwks) load() error {
+	data, err := s.read()
+	if err != nil {
+		return fmt.Errorf("read jwks: %w", err)
+	}
+
+	var doc struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("decode jwks: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(doc.Keys))
+	for _, k := range doc.Keys {
+		key, err := k.publicKey()
+		if err != nil {
+			return fmt.Errorf("jwks key %q: %w", k.Kid, err)
+		}
+		if key != nil {
+			keys[k.Kid] = key
+		}
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.fetchedAt = time.Now()
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *jwks) read() ([]byte, error) {
+	if !strings.HasPrefix(s.source, "http://") && !strings.HasPrefix(s.source, "https://") {
+		return os.ReadFile(s.source)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	res, err := client.Get(s.source)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", res.Status)
+	}
+
+	return io.ReadAll(res.Body)
+}
+
+func (k *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, nil
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid ed25519 key size %d", len(x))
+		}
+
+		return ed25519.PublicKey(x), nil
+	}
+
+	return nil, nil
+}
+
+func parsePublicKey(data []byte) (crypto.PublicKey, error) {
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+		return key, nil
+	}
+
+	key, err := jwt.ParseEdPublicKeyFromPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("public key is neither RSA nor Ed25519: %w", err)
+	}
+
+	return key, nil
+}
+
+func checkMethod(token *jwt.Token, key crypto.PublicKey) (interface{}, error) {
+	switch key.(type) {
+	case *rsa.PublicKey:
+		switch token.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+			return key, nil
+		}
+	case ed25519.PublicKey:
+		if _, ok := token.Method.(*jwt.SigningMethodEd25519); ok {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("signing method %s does not match key", token.Method.Alg())
+}
@@ -0,0 +1,97 @@
+package authservice
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeJWKS(t *testing.T, path string, kids ...string) {
+	t.Helper()
+
+	var doc struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	for _, kid := range kids {
+		pub, _, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc.Keys = append(doc.Keys, jsonWebKey{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			Kid: kid,
+			X:   base64.RawURLEncoding.EncodeToString(pub),
+		})
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestJWKSRefreshDropsRemovedKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, "old")
+
+	set, err := newJWKS(path, 10*time.Millisecond, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := set.key("old"); err != nil {
+		t.Fatalf("key(old): %v", err)
+	}
+
+	writeJWKS(t, path, "new")
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		_, oldOk := set.lookup("old")
+		_, newOk := set.lookup("new")
+		if !oldOk && newOk {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("key set was not replaced by the periodic refresh")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestJWKSUnknownKidFetchesAtMostOncePerInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, "a")
+
+	set, err := newJWKS(path, time.Hour, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the initial load just happened, so an unknown kid must not refetch
+	writeJWKS(t, path, "b")
+	if _, err := set.key("b"); err == nil {
+		t.Fatal("key(b) fetched again within the minimum interval")
+	}
+
+	set.mu.Lock()
+	set.fetchedAt = time.Now().Add(-minFetchInterval)
+	set.mu.Unlock()
+
+	if _, err := set.key("b"); err != nil {
+		t.Fatalf("key(b) after the minimum interval: %v", err)
+	}
+	if _, ok := set.lookup("a"); ok {
+		t.Fatal("removed key a is still trusted")
+	}
+}
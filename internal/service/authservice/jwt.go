@@ -0,0 +1,137 @@
+package authservice
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/mzhn-sochi/gateway/api/auth"
+	"github.com/mzhn-sochi/gateway/internal/config"
+	"github.com/mzhn-sochi/gateway/internal/entity"
+)
+
+var (
+	hmacMethods = []string{"HS256", "HS384", "HS512"}
+	keyMethods  = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "EdDSA"}
+)
+
+type verifier struct {
+	parser      *jwt.Parser
+	keyfunc     jwt.Keyfunc
+	userIdClaim string
+	roleClaim   string
+}
+
+func newVerifier(cfg *config.Config, logger *slog.Logger) (*verifier, error) {
+	c := cfg.Auth.JWT
+
+	v := &verifier{
+		userIdClaim: c.UserIdClaim,
+		roleClaim:   c.RoleClaim,
+	}
+
+	methods := keyMethods
+
+	switch {
+	case c.Secret != "":
+		secret := []byte(c.Secret)
+		methods = hmacMethods
+		v.keyfunc = func(token *jwt.Token) (interface{}, error) {
+			return secret, nil
+		}
+
+	case c.PublicKeyFile != "":
+		data, err := os.ReadFile(c.PublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("read public key: %w", err)
+		}
+
+		key, err := parsePublicKey(data)
+		if err != nil {
+			return nil, err
+		}
+
+		v.keyfunc = func(token *jwt.Token) (interface{}, error) {
+			return checkMethod(token, key)
+		}
+
+	case c.JWKS != "":
+		set, err := newJWKS(c.JWKS, c.JWKSRefresh, logger)
+		if err != nil {
+			return nil, err
+		}
+
+		v.keyfunc = func(token *jwt.Token) (interface{}, error) {
+			kid, _ := token.Header["kid"].(string)
+
+			key, err := set.key(kid)
+			if err != nil {
+				return nil, err
+			}
+
+			return checkMethod(token, key)
+		}
+
+	default:
+		return nil, errors.New("local auth mode requires AUTH_JWT_SECRET, AUTH_JWT_PUBLIC_KEY_FILE or AUTH_JWT_JWKS")
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+	}
+
+	if c.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(c.Issuer))
+	}
+
+	v.parser = jwt.NewParser(opts...)
+
+	return v, nil
+}
+
+func (v *verifier) verify(accessToken string) (*entity.UserClaims, error) {
+	claims := jwt.MapClaims{}
+
+	if _, err := v.parser.ParseWithClaims(accessToken, claims, v.keyfunc); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnauthorized, err.Error())
+	}
+
+	id, ok := claims[v.userIdClaim].(string)
+	if !ok || id == "" {
+		return nil, fmt.Errorf("%w: missing %s claim", ErrUnauthorized, v.userIdClaim)
+	}
+
+	role, err := parseRole(claims[v.roleClaim])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnauthorized, err.Error())
+	}
+
+	return &entity.UserClaims{
+		Id:   id,
+		Role: role,
+	}, nil
+}
+
+func parseRole(claim interface{}) (entity.Role, error) {
+	switch r := claim.(type) {
+	case float64:
+		if _, ok := auth.Role_name[int32(r)]; ok {
+			return entity.Role(r), nil
+		}
+	case string:
+		if v, ok := auth.Role_value[r]; ok {
+			return entity.Role(v), nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid role claim %v", claim)
+}
+
+// permitted mirrors the auth service: admins pass every check, everyone else
+// must hold exactly the required role.
+func permitted(actual entity.Role, required auth.Role) bool {
+	return actual == entity.Role(auth.Role_admin) || actual == entity.Role(required)
+}
@@ -0,0 +1,7 @@
+package s3service
+
+import "errors"
+
+var (
+	ErrInvalidImage = errors.New("invalid image")
+)
@@ -2,57 +2,56 @@ package s3service
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"github.com/mzhn-sochi/gateway/api/s3"
 	"github.com/mzhn-sochi/gateway/internal/config"
+	"github.com/mzhn-sochi/gateway/internal/grpcconn"
+	"github.com/mzhn-sochi/gateway/internal/metrics"
 	"github.com/mzhn-sochi/gateway/pkg/file"
 	"github.com/mzhn-sochi/gateway/pkg/middleware"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
+	"io"
 	"log/slog"
-	"math"
 )
 
+const chunkSize = 1 << 20
+
 type S3Service struct {
-	config *config.Config
-	client s3.S3Client
+	config  *config.Config
+	client  s3.S3Client
+	conn    *grpc.ClientConn
+	metrics *metrics.Metrics
 }
 
-func New(config *config.Config, logger *slog.Logger) *S3Service {
-
-	l := logger.With("service", "s3")
-
-	host := config.Services.S3Service.Host
-	port := config.Services.S3Service.Port
-
-	l.Info("connecting to grpc service", slog.String("host", host), slog.Int("port", port))
-
-	conn, err := grpc.NewClient(
-		fmt.Sprintf("%s:%d", host, port),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+func New(config *config.Config, metrics *metrics.Metrics, conns *grpcconn.Manager) (*S3Service, error) {
+	c := config.Services.S3Service
+	conn, err := conns.Dial(grpcconn.Target{
+		Name:       "s3",
+		Host:       c.Host,
+		Port:       c.Port,
+		Resilience: c.Resilience,
+		TLS:        c.TLS,
+	})
 	if err != nil {
-		l.Error("error with connection to grpc service", slog.String("err", err.Error()))
-		panic(err)
+		return nil, err
 	}
 
 	client := s3.NewS3Client(conn)
 
 	return &S3Service{
-		config: config,
-		client: client,
-	}
+		config:  config,
+		client:  client,
+		conn:    conn,
+		metrics: metrics,
+	}, nil
 }
 
 func (s *S3Service) Upload(ctx context.Context, reader file.Reader) (string, error) {
 
 	l := ctx.Value(middleware.LOGGER).(*slog.Logger).With("service", "s3").With("method", "upload")
 
-	chunkCount := int(math.Ceil(float64(reader.Size()) / (1 << 20)))
-	l.Debug("chunk count", slog.Int("count", chunkCount))
-
 	stream, err := s.client.Upload(ctx)
 	if err != nil {
 		return "", err
@@ -60,34 +59,47 @@ func (s *S3Service) Upload(ctx context.Context, reader file.Reader) (string, err
 
 	l.Debug(
 		"send image",
-		slog.Int("count", chunkCount),
 		slog.String("contentType", reader.ContentType()),
 		slog.Int64("size", reader.Size()),
 	)
 
-	for i := 0; i < chunkCount; i++ {
+	var (
+		sent   int64
+		chunks int
+	)
+	defer func() {
+		s.metrics.ObserveUpload(sent, chunks)
+	}()
+
+	chunk := make([]byte, chunkSize)
+	for {
+		read, err := io.ReadFull(reader, chunk)
+		if read > 0 {
+			// Send marshals the message before returning, so the buffer
+			// can be reused for the next chunk
+			serr := stream.Send(&s3.Object{
+				Image: &s3.Image{
+					Chunk: chunk[:read],
+				},
+				Meta: &s3.Metadata{
+					ContentType: reader.ContentType(),
+				},
+			})
+			if serr != nil {
+				return "", serr
+			}
 
-		chunk := make([]byte, 1<<20)
+			sent += int64(read)
+			chunks++
+			l.Debug("sent image chunk", slog.Int("chunk", chunks), slog.Int("size", read))
+		}
 
-		read, err := reader.Read(chunk)
-		if err != nil {
-			return "", err
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
 		}
-		l.Debug("read bytes", slog.Int("read", read))
-
-		l.Debug("sending image chunk", slog.Int("chunk", i))
-		err = stream.Send(&s3.Object{
-			Image: &s3.Image{
-				Chunk: chunk,
-			},
-			Meta: &s3.Metadata{
-				ContentType: reader.ContentType(),
-			},
-		})
 		if err != nil {
 			return "", err
 		}
-		l.Debug("sent image chunk", slog.Int("chunk", i))
 	}
 
 	reply, err := stream.CloseAndRecv()
@@ -95,7 +107,7 @@ func (s *S3Service) Upload(ctx context.Context, reader file.Reader) (string, err
 		if e, ok := status.FromError(err); ok {
 			if e.Code() == codes.InvalidArgument {
 				l.Error("grpc error", slog.String("err", e.String()))
-				return "", fmt.Errorf("invalid image")
+				return "", ErrInvalidImage
 			}
 		}
 
@@ -106,3 +118,24 @@ func (s *S3Service) Upload(ctx context.Context, reader file.Reader) (string, err
 
 	return reply.Name, nil
 }
+
+// Delete removes an uploaded object, e.g. when the ticket it was uploaded
+// for could not be created.
+func (s *S3Service) Delete(ctx context.Context, name string) error {
+	if _, err := s.client.Delete(ctx, &s3.DeleteRequest{Name: name}); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *S3Service) Name() string {
+	return "s3"
+}
+
+func (s *S3Service) Conn() *grpc.ClientConn {
+	return s.conn
+}
@@ -2,14 +2,12 @@ package suggestions
 
 import (
 	"context"
-	"fmt"
 	"github.com/mzhn-sochi/gateway/api/shop_suggestions"
 	"github.com/mzhn-sochi/gateway/internal/config"
-	"github.com/mzhn-sochi/gateway/internal/controllers"
 	"github.com/mzhn-sochi/gateway/internal/entity"
+	"github.com/mzhn-sochi/gateway/internal/grpcconn"
 	"github.com/mzhn-sochi/gateway/pkg/middleware"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 	"log/slog"
 )
 
@@ -17,23 +15,22 @@ type Service struct {
 	config *config.Config
 	logger *slog.Logger
 	client shop_suggestions.ShopSuggestionsServiceClient
+	conn   *grpc.ClientConn
 }
 
-func New(config *config.Config, logger *slog.Logger) controllers.SuggestionsService {
+func New(config *config.Config, logger *slog.Logger, conns *grpcconn.Manager) (*Service, error) {
 	l := logger.With("service", "suggestions")
 
-	host := config.Services.Suggestions.Host
-	port := config.Services.Suggestions.Port
-
-	l.Info("connecting to grpc service", slog.String("host", host), slog.Int("port", port))
-
-	conn, err := grpc.NewClient(
-		fmt.Sprintf("%s:%d", host, port),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	c := config.Services.Suggestions
+	conn, err := conns.Dial(grpcconn.Target{
+		Name:       "suggestions",
+		Host:       c.Host,
+		Port:       c.Port,
+		Resilience: c.Resilience,
+		TLS:        c.TLS,
+	})
 	if err != nil {
-		l.Error("error with connection to grpc service", slog.String("err", err.Error()))
-		panic(err)
+		return nil, err
 	}
 
 	client := shop_suggestions.NewShopSuggestionsServiceClient(conn)
@@ -41,8 +38,9 @@ func New(config *config.Config, logger *slog.Logger) controllers.SuggestionsServ
 	return &Service{
 		config: config,
 		client: client,
+		conn:   conn,
 		logger: l,
-	}
+	}, nil
 }
 
 func (s *Service) GetSuggestions(ctx context.Context, lon, lat float32, count uint32) ([]*entity.Suggestion, error) {
@@ -70,3 +68,11 @@ func (s *Service) GetSuggestions(ctx context.Context, lon, lat float32, count ui
 
 	return suggestions, nil
 }
+
+func (s *Service) Name() string {
+	return "suggestions"
+}
+
+func (s *Service) Conn() *grpc.ClientConn {
+	return s.conn
+}
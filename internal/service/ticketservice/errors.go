@@ -3,5 +3,7 @@ package ticketservice
 import "errors"
 
 var (
-	ErrTicketNotFound = errors.New("ticket not found")
+	ErrTicketNotFound    = errors.New("ticket not found")
+	ErrInvalidStatus     = errors.New("invalid ticket status")
+	ErrInvalidTransition = errors.New("illegal ticket status transition")
 )
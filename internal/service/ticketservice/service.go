@@ -7,13 +7,14 @@ import (
 	"github.com/mzhn-sochi/gateway/internal/config"
 	"github.com/mzhn-sochi/gateway/internal/entity"
 	"github.com/mzhn-sochi/gateway/internal/entity/dto"
+	"github.com/mzhn-sochi/gateway/internal/grpcconn"
 	"github.com/mzhn-sochi/gateway/pkg/middleware"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
-	"log"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"log/slog"
+	"strings"
 )
 
 //var _ controllers.TicketsService = (*Service)(nil)
@@ -21,24 +22,20 @@ import (
 type Service struct {
 	config *config.Config
 	client ts.TicketServiceClient
+	conn   *grpc.ClientConn
 }
 
-func New(config *config.Config, logger *slog.Logger) *Service {
-
-	l := logger.With("service", "ts")
-
-	host := config.Services.TicketService.Host
-	port := config.Services.TicketService.Port
-
-	l.Info("connecting to grpc service", slog.String("host", host), slog.Int("port", port))
-
-	conn, err := grpc.NewClient(
-		fmt.Sprintf("%s:%d", host, port),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+func New(config *config.Config, conns *grpcconn.Manager) (*Service, error) {
+	c := config.Services.TicketService
+	conn, err := conns.Dial(grpcconn.Target{
+		Name:       "tickets",
+		Host:       c.Host,
+		Port:       c.Port,
+		Resilience: c.Resilience,
+		TLS:        c.TLS,
+	})
 	if err != nil {
-		l.Error("error with connection to grpc service", slog.String("err", err.Error()))
-		panic(err)
+		return nil, err
 	}
 
 	client := ts.NewTicketServiceClient(conn)
@@ -46,7 +43,8 @@ func New(config *config.Config, logger *slog.Logger) *Service {
 	return &Service{
 		config: config,
 		client: client,
-	}
+		conn:   conn,
+	}, nil
 }
 
 func (s *Service) Find(ctx context.Context, id string) (*entity.Ticket, error) {
@@ -57,43 +55,35 @@ func (s *Service) Find(ctx context.Context, id string) (*entity.Ticket, error) {
 		return nil, err
 	}
 
-	log.Printf("ticket from req %+v", ticket.Item)
-
-	var item *entity.Item
-	if ticket.Item != nil {
-		item = &entity.Item{
-			Product:     ticket.Item.Product,
-			Description: ticket.Item.Description,
-			Price:       ticket.Item.Price,
-			Amount:      ticket.Item.Amount,
-			Unit:        ticket.Item.Unit,
-			Overprice:   ticket.Item.Overprice,
-		}
-	}
-
-	log.Printf("item %+v", item)
-
-	return &entity.Ticket{
-		Id:          ticket.Id,
-		UserId:      ticket.UserId,
-		ImageUrl:    ticket.ImageUrl,
-		Status:      entity.Role(ticket.Status),
-		ShopName:    ticket.ShopName,
-		ShopAddress: ticket.ShopAddress,
-		CreatedAt:   ticket.CreatedAt,
-		UpdatedAt:   ticket.UpdatedAt,
-		Reason:      ticket.Reason,
-		Item:        item,
-	}, nil
+	return ticketFromProto(ticket), nil
 }
+
 func (s *Service) List(ctx context.Context, filters *entity.TicketFilters) ([]*entity.Ticket, uint64, error) {
 
 	logger := ctx.Value(middleware.LOGGER).(*slog.Logger)
 
+	filter := &ts.Filter{
+		UserId: filters.UserId,
+	}
+
+	if filters.Status != nil {
+		v, err := entity.ParseTicketStatus(*filters.Status)
+		if err != nil {
+			return nil, 0, ErrInvalidStatus
+		}
+		st := ts.Statuses(v)
+		filter.Status = &st
+	}
+
+	if filters.From != nil || filters.To != nil {
+		filter.TimeRange = &ts.TimeRange{
+			From: filters.From,
+			To:   filters.To,
+		}
+	}
+
 	req := &ts.ListRequest{
-		Filter: &ts.Filter{
-			UserId: filters.UserId,
-		},
+		Filter: filter,
 		Bounds: &ts.Bounds{
 			Limit:  filters.Limit,
 			Offset: filters.Offset,
@@ -108,31 +98,7 @@ func (s *Service) List(ctx context.Context, filters *entity.TicketFilters) ([]*e
 
 	tt := make([]*entity.Ticket, 0, len(response.Tickets))
 	for _, t := range response.Tickets {
-
-		var item *entity.Item
-		if t.Item != nil {
-			item = &entity.Item{
-				Product:     t.Item.Product,
-				Description: t.Item.Description,
-				Price:       t.Item.Price,
-				Amount:      t.Item.Amount,
-				Unit:        t.Item.Unit,
-				Overprice:   t.Item.Overprice,
-			}
-		}
-
-		tt = append(tt, &entity.Ticket{
-			Id:          t.Id,
-			UserId:      t.UserId,
-			Status:      entity.Role(t.Status),
-			ImageUrl:    t.ImageUrl,
-			ShopName:    t.ShopName,
-			ShopAddress: t.ShopAddress,
-			CreatedAt:   t.CreatedAt,
-			UpdatedAt:   t.UpdatedAt,
-			Reason:      t.Reason,
-			Item:        item,
-		})
+		tt = append(tt, ticketFromProto(t))
 	}
 
 	return tt, uint64(response.Count), nil
@@ -147,6 +113,10 @@ func (s *Service) Create(ctx context.Context, t *dto.CreateTicket) (string, erro
 		ShopName: t.ShopName,
 	}
 
+	for _, img := range t.Images {
+		req.Images = append(req.Images, &ts.Image{Url: img.Url, Role: imageRoles[img.Role]})
+	}
+
 	l.Debug("ticket service create request", slog.Any("request", req))
 
 	response, err := s.client.Create(ctx, req)
@@ -158,19 +128,243 @@ func (s *Service) Create(ctx context.Context, t *dto.CreateTicket) (string, erro
 }
 
 func (s *Service) Close(ctx context.Context, id string) error {
+	if err := s.transition(ctx, id, entity.StatusClosed); err != nil {
+		return err
+	}
 
 	req := &ts.CloseTicketRequest{TicketId: id}
 
 	if _, err := s.client.CloseTicket(ctx, req); err != nil {
-		if e, ok := status.FromError(err); ok {
-			switch e.Code() {
-			case codes.NotFound:
-				return ErrTicketNotFound
-			}
+		return moderationError("closing", err)
+	}
+
+	return nil
+}
+
+// Approve closes a ticket waiting for approval.
+func (s *Service) Approve(ctx context.Context, id string) error {
+	if err := s.transition(ctx, id, entity.StatusClosed); err != nil {
+		return err
+	}
+
+	if _, err := s.client.Approve(ctx, &ts.ApproveRequest{TicketId: id}); err != nil {
+		return moderationError("approving", err)
+	}
+
+	return nil
+}
+
+// Reject closes a ticket as rejected, recording the moderator's reason.
+func (s *Service) Reject(ctx context.Context, id, reason string) error {
+	if err := s.transition(ctx, id, entity.StatusRejected); err != nil {
+		return err
+	}
+
+	if _, err := s.client.Reject(ctx, &ts.RejectRequest{TicketId: id, Reason: reason}); err != nil {
+		return moderationError("rejecting", err)
+	}
+
+	return nil
+}
+
+// UpdateItem applies a moderator's correction to the recognized item and
+// returns the updated ticket. Only the corrected fields are sent, so
+// concurrent corrections of other fields are not overwritten.
+func (s *Service) UpdateItem(ctx context.Context, id string, update *dto.UpdateItem) (*entity.Ticket, error) {
+	t, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkEditable(entity.TicketStatus(t.Status)); err != nil {
+		return nil, err
+	}
+
+	item := &ts.Item{}
+	mask := &fieldmaskpb.FieldMask{}
+
+	if update.Product != nil {
+		item.Product = *update.Product
+		mask.Paths = append(mask.Paths, "product")
+	}
+	if update.Description != nil {
+		item.Description = *update.Description
+		mask.Paths = append(mask.Paths, "description")
+	}
+	if update.Price != nil {
+		item.Price = *update.Price
+		mask.Paths = append(mask.Paths, "price")
+	}
+	if update.Amount != nil {
+		item.Amount = *update.Amount
+		mask.Paths = append(mask.Paths, "amount")
+	}
+	if update.Unit != nil {
+		item.Unit = *update.Unit
+		mask.Paths = append(mask.Paths, "unit")
+	}
+	if update.Overprice != nil {
+		item.Overprice = *update.Overprice
+		mask.Paths = append(mask.Paths, "overprice")
+	}
+
+	ticket, err := s.client.UpdateItem(ctx, &ts.UpdateItemRequest{TicketId: id, Item: item, UpdateMask: mask})
+	if err != nil {
+		return nil, moderationError("updating item of", err)
+	}
+
+	return ticketFromProto(ticket), nil
+}
+
+// SaveAnalysis stores a finished price tag recognition with the ticket.
+func (s *Service) SaveAnalysis(ctx context.Context, id string, a *entity.Analysis) error {
+	req := &ts.SaveAnalysisRequest{
+		TicketId: id,
+		Analysis: &ts.Analysis{
+			Status:    a.Status,
+			UpdatedAt: a.UpdatedAt,
+		},
+	}
+
+	if a.Error != "" {
+		req.Analysis.Error = &a.Error
+	}
+
+	if r := a.Result; r != nil {
+		req.Analysis.Result = &ts.AnalysisResult{
+			Product:     r.Product,
+			Description: r.Description,
+			Price:       r.Price,
+			Attributes:  r.Attributes,
+		}
+		if r.Measure != nil {
+			req.Analysis.Result.Measure = &ts.Measure{Amount: r.Measure.Amount, Unit: r.Measure.Unit}
 		}
+	}
 
-		return fmt.Errorf("error with closing ticket: %w", err)
+	if _, err := s.client.SaveAnalysis(ctx, req); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return ErrTicketNotFound
+		}
+		return fmt.Errorf("error with saving analysis of ticket: %w", err)
 	}
 
 	return nil
 }
+
+func (s *Service) find(ctx context.Context, id string) (*ts.Ticket, error) {
+	t, err := s.client.FindById(ctx, &ts.FindByIdRequest{TicketId: id})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrTicketNotFound
+		}
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// transition checks the ticket's current status against the transition table.
+func (s *Service) transition(ctx context.Context, id string, to entity.TicketStatus) error {
+	t, err := s.find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return checkTransition(entity.TicketStatus(t.Status), to)
+}
+
+func moderationError(action string, err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return ErrTicketNotFound
+	case codes.FailedPrecondition:
+		return ErrInvalidTransition
+	}
+
+	return fmt.Errorf("error with %s ticket: %w", action, err)
+}
+
+func ticketFromProto(t *ts.Ticket) *entity.Ticket {
+	var item *entity.Item
+	if t.Item != nil {
+		item = &entity.Item{
+			Product:     t.Item.Product,
+			Description: t.Item.Description,
+			Price:       t.Item.Price,
+			Amount:      t.Item.Amount,
+			Unit:        t.Item.Unit,
+			Overprice:   t.Item.Overprice,
+		}
+	}
+
+	images := make([]entity.Image, 0, len(t.Images))
+	for _, img := range t.Images {
+		images = append(images, entity.Image{Url: img.Url, Role: imageRole(img.Role)})
+	}
+
+	return &entity.Ticket{
+		Id:          t.Id,
+		UserId:      t.UserId,
+		ImageUrl:    t.ImageUrl,
+		Status:      entity.TicketStatus(t.Status),
+		ShopName:    t.ShopName,
+		ShopAddress: t.ShopAddress,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+		Reason:      t.Reason,
+		Item:        item,
+		Images:      images,
+		Analysis:    analysisFromProto(t.Id, t.Analysis),
+	}
+}
+
+func analysisFromProto(ticketId string, a *ts.Analysis) *entity.Analysis {
+	if a == nil {
+		return nil
+	}
+
+	analysis := &entity.Analysis{
+		TicketId:  ticketId,
+		Status:    a.Status,
+		Error:     a.GetError(),
+		UpdatedAt: a.UpdatedAt,
+	}
+
+	if r := a.Result; r != nil {
+		analysis.Result = &entity.ImageInfo{
+			Product:     r.Product,
+			Description: r.Description,
+			Price:       r.Price,
+			Attributes:  r.Attributes,
+		}
+		if r.Measure != nil {
+			analysis.Result.Measure = &entity.Measure{Amount: r.Measure.Amount, Unit: r.Measure.Unit}
+		}
+	}
+
+	return analysis
+}
+
+var imageRoles = map[entity.ImageRole]ts.ImageRoles{
+	entity.ImageRolePricetag: ts.ImageRoles_PRICETAG,
+	entity.ImageRoleReceipt:  ts.ImageRoles_RECEIPT,
+	entity.ImageRoleShelf:    ts.ImageRoles_SHELF,
+}
+
+func imageRole(r ts.ImageRoles) entity.ImageRole {
+	for role, v := range imageRoles {
+		if v == r {
+			return role
+		}
+	}
+	return entity.ImageRole(strings.ToLower(r.String()))
+}
+
+func (s *Service) Name() string {
+	return "tickets"
+}
+
+func (s *Service) Conn() *grpc.ClientConn {
+	return s.conn
+}
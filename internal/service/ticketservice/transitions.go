@@ -0,0 +1,38 @@
+package ticketservice
+
+import (
+	"fmt"
+	"github.com/mzhn-sochi/gateway/internal/entity"
+)
+
+// transitions lists the statuses a ticket may move to from each status.
+// CLOSED and REJECTED are final.
+var transitions = map[entity.TicketStatus][]entity.TicketStatus{
+	entity.StatusWaitingOCR:        {entity.StatusWaitingValidation, entity.StatusRejected},
+	entity.StatusWaitingValidation: {entity.StatusWaitingApproval, entity.StatusRejected},
+	entity.StatusWaitingApproval:   {entity.StatusClosed, entity.StatusRejected},
+}
+
+// editable lists the statuses in which moderators may correct the recognized item.
+var editable = map[entity.TicketStatus]bool{
+	entity.StatusWaitingValidation: true,
+	entity.StatusWaitingApproval:   true,
+}
+
+// checkTransition returns ErrInvalidTransition unless the table allows moving
+// a ticket from one status to the other.
+func checkTransition(from, to entity.TicketStatus) error {
+	for _, s := range transitions[from] {
+		if s == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, from, to)
+}
+
+func checkEditable(st entity.TicketStatus) error {
+	if !editable[st] {
+		return fmt.Errorf("%w: item of a %s ticket cannot be edited", ErrInvalidTransition, st)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package ticketservice
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mzhn-sochi/gateway/internal/entity"
+)
+
+func TestCheckTransition(t *testing.T) {
+	type pair struct{ from, to entity.TicketStatus }
+
+	allowed := map[pair]bool{
+		{entity.StatusWaitingOCR, entity.StatusWaitingValidation}:      true,
+		{entity.StatusWaitingOCR, entity.StatusRejected}:               true,
+		{entity.StatusWaitingValidation, entity.StatusWaitingApproval}: true,
+		{entity.StatusWaitingValidation, entity.StatusRejected}:        true,
+		{entity.StatusWaitingApproval, entity.StatusClosed}:            true,
+		{entity.StatusWaitingApproval, entity.StatusRejected}:          true,
+	}
+
+	for _, from := range entity.TicketStatuses {
+		for _, to := range entity.TicketStatuses {
+			t.Run(from.String()+"->"+to.String(), func(t *testing.T) {
+				err := checkTransition(from, to)
+
+				if allowed[pair{from, to}] {
+					if err != nil {
+						t.Fatalf("checkTransition failed: %v", err)
+					}
+					return
+				}
+
+				if !errors.Is(err, ErrInvalidTransition) {
+					t.Fatalf("checkTransition = %v, want %v", err, ErrInvalidTransition)
+				}
+			})
+		}
+	}
+}
+
+func TestCheckEditable(t *testing.T) {
+	tests := []struct {
+		status   entity.TicketStatus
+		editable bool
+	}{
+		{entity.StatusWaitingOCR, false},
+		{entity.StatusWaitingValidation, true},
+		{entity.StatusWaitingApproval, true},
+		{entity.StatusClosed, false},
+		{entity.StatusRejected, false},
+		{entity.TicketStatus(7), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status.String(), func(t *testing.T) {
+			err := checkEditable(tt.status)
+
+			if tt.editable {
+				if err != nil {
+					t.Fatalf("checkEditable failed: %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, ErrInvalidTransition) {
+				t.Fatalf("checkEditable = %v, want %v", err, ErrInvalidTransition)
+			}
+		})
+	}
+}
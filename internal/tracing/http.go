@@ -0,0 +1,66 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/mzhn-sochi/gateway/internal/apierror"
+	"github.com/mzhn-sochi/gateway/pkg/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func (t *Tracing) Middleware(mapping *apierror.Mapping) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		header := make(http.Header)
+		for k, v := range c.GetReqHeaders() {
+			header[k] = v
+		}
+
+		ctx := t.propagator.Extract(context.Background(), propagation.HeaderCarrier(header))
+
+		method := utils.CopyString(c.Method())
+		path := utils.CopyString(c.Path())
+
+		ctx, span := t.tracer.Start(ctx, method+" "+path,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(method),
+				semconv.URLPath(path),
+			),
+		)
+		defer span.End()
+
+		if rid, ok := c.Locals(middleware.REQUEST_ID).(string); ok {
+			span.SetAttributes(attribute.String("request.id", rid))
+		}
+
+		c.Locals(SPAN, span)
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+
+		code := c.Response().StatusCode()
+		if err != nil {
+			code = mapping.StatusCode(err)
+		}
+
+		route := c.Route().Path
+		span.SetName(method + " " + route)
+		span.SetAttributes(semconv.HTTPRoute(route), semconv.HTTPResponseStatusCode(code))
+
+		if code >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(code))
+		}
+		if err != nil {
+			span.RecordError(err)
+		}
+
+		return err
+	}
+}
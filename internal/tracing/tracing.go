@@ -0,0 +1,130 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/mzhn-sochi/gateway/internal/config"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// SPAN is the fiber local holding the server span of the current request.
+const SPAN = "span"
+
+type Tracing struct {
+	provider   *sdktrace.TracerProvider
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+func New(config *config.Config, logger *slog.Logger) *Tracing {
+	l := logger.With("service", "tracing")
+
+	c := config.Tracing
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName(c.ServiceName)))
+	if err != nil {
+		l.Error("error with tracing resource", slog.String("err", err.Error()))
+		panic(err)
+	}
+
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(c.SampleRatio))),
+	}
+
+	switch c.Exporter {
+	case "none":
+	case "stdout":
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			l.Error("error with stdout exporter", slog.String("err", err.Error()))
+			panic(err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	case "otlp":
+		eopts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(c.Endpoint)}
+		if c.Insecure {
+			eopts = append(eopts, otlptracegrpc.WithInsecure())
+		}
+
+		exporter, err := otlptracegrpc.New(context.Background(), eopts...)
+		if err != nil {
+			l.Error("error with otlp exporter", slog.String("err", err.Error()))
+			panic(err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	default:
+		panic(fmt.Errorf("unknown tracing exporter %q", c.Exporter))
+	}
+
+	l.Info("tracing configured", slog.String("exporter", c.Exporter))
+
+	provider := sdktrace.NewTracerProvider(opts...)
+	propagator := propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagator)
+
+	return &Tracing{
+		provider:   provider,
+		tracer:     provider.Tracer("github.com/mzhn-sochi/gateway"),
+		propagator: propagator,
+	}
+}
+
+// DialOptions instruments a gRPC client connection: every call gets a client
+// span that is a child of the HTTP request span, and the trace context is sent
+// to the upstream in the call metadata.
+func (t *Tracing) DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+			otelgrpc.WithTracerProvider(t.provider),
+			otelgrpc.WithPropagators(t.propagator),
+		)),
+		grpc.WithChainUnaryInterceptor(unarySpanInterceptor()),
+		grpc.WithChainStreamInterceptor(streamSpanInterceptor()),
+	}
+}
+
+func (t *Tracing) Shutdown(ctx context.Context) error {
+	return t.provider.Shutdown(ctx)
+}
+
+// withRequestSpan makes the server span stored in fiber locals visible to
+// otel. Handlers pass the fasthttp request context to the services, which
+// exposes locals through Value but not the context created by tracer.Start.
+func withRequestSpan(ctx context.Context) context.Context {
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+
+	if span, ok := ctx.Value(SPAN).(trace.Span); ok {
+		return trace.ContextWithSpan(ctx, span)
+	}
+
+	return ctx
+}
+
+func unarySpanInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(withRequestSpan(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+func streamSpanInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(withRequestSpan(ctx), desc, cc, method, opts...)
+	}
+}
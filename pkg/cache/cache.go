@@ -0,0 +1,244 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Stats struct {
+	Hits        uint64 `json:"hits"`
+	Misses      uint64 `json:"misses"`
+	Loads       uint64 `json:"loads"`
+	Shared      uint64 `json:"shared"`
+	Evictions   uint64 `json:"evictions"`
+	Expirations uint64 `json:"expirations"`
+	Size        int    `json:"size"`
+}
+
+const DefaultLoadTimeout = 10 * time.Second
+
+type LoadFunc[V any] func(ctx context.Context) (V, error)
+
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+type call[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// Cache is a size bounded LRU cache with per entry TTL. Concurrent loads of
+// the same key are collapsed into a single call of the loader.
+type Cache[K comparable, V any] struct {
+	mu       sync.Mutex
+	size     int
+	ttl      time.Duration
+	ll       *list.List
+	items    map[K]*list.Element
+	inflight map[K]*call[V]
+
+	loadTimeout time.Duration
+
+	hits        atomic.Uint64
+	misses      atomic.Uint64
+	loads       atomic.Uint64
+	shared      atomic.Uint64
+	evictions   atomic.Uint64
+	expirations atomic.Uint64
+}
+
+func New[K comparable, V any](size int, ttl time.Duration) *Cache[K, V] {
+	return &Cache[K, V]{
+		size:     size,
+		ttl:      ttl,
+		ll:       list.New(),
+		items:    make(map[K]*list.Element),
+		inflight: make(map[K]*call[V]),
+
+		loadTimeout: DefaultLoadTimeout,
+	}
+}
+
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, ok := c.get(key)
+	if ok {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+
+	return v, ok
+}
+
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(key, value)
+}
+
+// GetOrLoad returns the cached value for key or calls load to obtain it.
+// Callers asking for a key that is already being loaded wait for that load.
+// The load is detached from the caller that started it, so that its
+// cancellation does not fail the other waiters, and is bounded by the load
+// timeout instead.
+func (c *Cache[K, V]) GetOrLoad(ctx context.Context, key K, load LoadFunc[V]) (V, error) {
+	c.mu.Lock()
+
+	if v, ok := c.get(key); ok {
+		c.mu.Unlock()
+		c.hits.Add(1)
+		return v, nil
+	}
+	c.misses.Add(1)
+
+	cl, ok := c.inflight[key]
+	if ok {
+		c.shared.Add(1)
+	} else {
+		cl = &call[V]{done: make(chan struct{})}
+		c.inflight[key] = cl
+		c.loads.Add(1)
+		go c.load(ctx, key, cl, load)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-cl.done:
+		return cl.value, cl.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+func (c *Cache[K, V]) load(ctx context.Context, key K, cl *call[V], load LoadFunc[V]) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.loadTimeout)
+
+	defer func() {
+		cancel()
+
+		if r := recover(); r != nil {
+			cl.err = fmt.Errorf("cache: load panicked: %v", r)
+		}
+
+		c.mu.Lock()
+		delete(c.inflight, key)
+		if cl.err == nil {
+			c.set(key, cl.value)
+		}
+		c.mu.Unlock()
+
+		close(cl.done)
+	}()
+
+	cl.value, cl.err = load(ctx)
+}
+
+// SetLoadTimeout bounds loads started by GetOrLoad.
+func (c *Cache[K, V]) SetLoadTimeout(timeout time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.loadTimeout = timeout
+}
+
+func (c *Cache[K, V]) Delete(keys ...K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if el, ok := c.items[key]; ok {
+			c.remove(el)
+		}
+	}
+}
+
+func (c *Cache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ll.Init()
+	c.items = make(map[K]*list.Element)
+}
+
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.ll.Len()
+}
+
+func (c *Cache[K, V]) Stats() Stats {
+	return Stats{
+		Hits:        c.hits.Load(),
+		Misses:      c.misses.Load(),
+		Loads:       c.loads.Load(),
+		Shared:      c.shared.Load(),
+		Evictions:   c.evictions.Load(),
+		Expirations: c.expirations.Load(),
+		Size:        c.Len(),
+	}
+}
+
+func (c *Cache[K, V]) get(key K) (V, bool) {
+	var zero V
+
+	el, ok := c.items[key]
+	if !ok {
+		return zero, false
+	}
+
+	e := el.Value.(*entry[K, V])
+	if c.ttl > 0 && time.Now().After(e.expiresAt) {
+		c.remove(el)
+		c.expirations.Add(1)
+		return zero, false
+	}
+
+	c.ll.MoveToFront(el)
+	return e.value, true
+}
+
+func (c *Cache[K, V]) set(key K, value V) {
+	if c.size <= 0 {
+		return
+	}
+
+	expiresAt := time.Now().Add(c.ttl)
+
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*entry[K, V])
+		e.value = value
+		e.expiresAt = expiresAt
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(&entry[K, V]{
+		key:       key,
+		value:     value,
+		expiresAt: expiresAt,
+	})
+
+	for c.ll.Len() > c.size {
+		c.remove(c.ll.Back())
+		c.evictions.Add(1)
+	}
+}
+
+func (c *Cache[K, V]) remove(el *list.Element) {
+	c.ll.Remove(el)
+	delete(c.items, el.Value.(*entry[K, V]).key)
+}
@@ -0,0 +1,157 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGetOrLoadSharesLoad(t *testing.T) {
+	c := New[string, int](10, time.Minute)
+
+	release := make(chan struct{})
+	var calls int
+	load := func(ctx context.Context) (int, error) {
+		calls++
+		<-release
+		return 42, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]int, 5)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := c.GetOrLoad(context.Background(), "k", load)
+			if err != nil {
+				t.Errorf("GetOrLoad: %v", err)
+			}
+			results[i] = v
+		}()
+	}
+
+	waitInflight(t, c, "k")
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Fatalf("load called %d times, want 1", calls)
+	}
+	for i, v := range results {
+		if v != 42 {
+			t.Fatalf("result %d = %d, want 42", i, v)
+		}
+	}
+	if v, ok := c.Get("k"); !ok || v != 42 {
+		t.Fatalf("Get = %d, %v, want 42, true", v, ok)
+	}
+}
+
+func TestGetOrLoadLeaderCancelDoesNotFailWaiters(t *testing.T) {
+	c := New[string, int](10, time.Minute)
+
+	release := make(chan struct{})
+	load := func(ctx context.Context) (int, error) {
+		select {
+		case <-release:
+			return 7, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+
+	leader, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := c.GetOrLoad(leader, "k", load)
+		leaderErr <- err
+	}()
+	waitInflight(t, c, "k")
+
+	waiter := make(chan int, 1)
+	go func() {
+		v, err := c.GetOrLoad(context.Background(), "k", load)
+		if err != nil {
+			t.Errorf("waiter: %v", err)
+		}
+		waiter <- v
+	}()
+
+	cancel()
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("leader error = %v, want context.Canceled", err)
+	}
+
+	close(release)
+	if v := <-waiter; v != 7 {
+		t.Fatalf("waiter got %d, want 7", v)
+	}
+}
+
+func TestGetOrLoadTimeout(t *testing.T) {
+	c := New[string, int](10, time.Minute)
+	c.SetLoadTimeout(10 * time.Millisecond)
+
+	_, err := c.GetOrLoad(context.Background(), "k", func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestGetOrLoadPanic(t *testing.T) {
+	c := New[string, int](10, time.Minute)
+
+	_, err := c.GetOrLoad(context.Background(), "k", func(ctx context.Context) (int, error) {
+		panic("boom")
+	})
+	if err == nil {
+		t.Fatal("expected an error from a panicking load")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	v, err := c.GetOrLoad(ctx, "k", func(ctx context.Context) (int, error) {
+		return 1, nil
+	})
+	if err != nil || v != 1 {
+		t.Fatalf("GetOrLoad after panic = %d, %v, want 1, nil", v, err)
+	}
+}
+
+func TestGetOrLoadErrorIsNotCached(t *testing.T) {
+	c := New[string, int](10, time.Minute)
+
+	fail := errors.New("upstream down")
+	if _, err := c.GetOrLoad(context.Background(), "k", func(ctx context.Context) (int, error) {
+		return 0, fail
+	}); !errors.Is(err, fail) {
+		t.Fatalf("error = %v, want %v", err, fail)
+	}
+
+	if _, ok := c.Get("k"); ok {
+		t.Fatal("failed load was cached")
+	}
+}
+
+func waitInflight(t *testing.T, c *Cache[string, int], key string) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		c.mu.Lock()
+		_, ok := c.inflight[key]
+		c.mu.Unlock()
+		if ok {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("no load of %q started", key)
+}
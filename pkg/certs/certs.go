@@ -0,0 +1,226 @@
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type Options struct {
+	Enabled bool
+	// CAFile is a PEM bundle the server certificate is verified against.
+	// The system roots are used when it is empty.
+	CAFile string
+	// CertFile and KeyFile are the client certificate presented for mTLS.
+	CertFile string
+	KeyFile  string
+	// ServerName is the name the server certificate must carry, a host name
+	// or an IP address.
+	ServerName string
+}
+
+// Credentials builds transport credentials for a gRPC client. Plaintext
+// credentials are returned when TLS is disabled.
+func Credentials(opts Options, logger *slog.Logger) (credentials.TransportCredentials, error) {
+	if !opts.Enabled {
+		return insecure.NewCredentials(), nil
+	}
+
+	r, err := NewReloader(opts, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials.NewTLS(r.TLSConfig()), nil
+}
+
+// Reloader keeps the CA bundle and client certificate in sync with the files
+// on disk. Files are checked on every handshake and loaded again once their
+// modification time changes, so rotated certificates are picked up by new
+// connections without a restart.
+type Reloader struct {
+	opts   Options
+	logger *slog.Logger
+
+	mu       sync.RWMutex
+	pool     *x509.CertPool
+	cert     *tls.Certificate
+	modified map[string]time.Time
+}
+
+func NewReloader(opts Options, logger *slog.Logger) (*Reloader, error) {
+	if (opts.CertFile == "") != (opts.KeyFile == "") {
+		return nil, errors.New("client certificate and key must be set together")
+	}
+	// crypto/tls leaves ConnectionState.ServerName empty for IP addresses,
+	// verify would then accept any certificate of a trusted issuer
+	if opts.ServerName == "" {
+		return nil, errors.New("server name to verify the certificate against must be set")
+	}
+
+	r := &Reloader{
+		opts:     opts,
+		logger:   logger,
+		modified: make(map[string]time.Time),
+	}
+
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *Reloader) TLSConfig() *tls.Config {
+	c := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: r.opts.ServerName,
+		// the chain is verified in VerifyConnection against the current pool,
+		// which the static RootCAs field could not follow
+		InsecureSkipVerify: true,
+		VerifyConnection:   r.verify,
+	}
+
+	if r.opts.CertFile != "" {
+		c.GetClientCertificate = r.clientCertificate
+	}
+
+	return c
+}
+
+func (r *Reloader) verify(cs tls.ConnectionState) error {
+	r.refresh()
+
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("server presented no certificate")
+	}
+
+	r.mu.RLock()
+	pool := r.pool
+	r.mu.RUnlock()
+
+	intermediates := x509.NewCertPool()
+	for _, c := range cs.PeerCertificates[1:] {
+		intermediates.AddCert(c)
+	}
+
+	_, err := cs.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         pool,
+		Intermediates: intermediates,
+		DNSName:       r.opts.ServerName,
+	})
+
+	return err
+}
+
+func (r *Reloader) clientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	r.refresh()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.cert, nil
+}
+
+// refresh loads the files again if any of them changed. A failed reload
+// keeps the previous material so a half-written rotation does not break
+// new connections.
+func (r *Reloader) refresh() {
+	if !r.changed() {
+		return
+	}
+
+	if err := r.load(); err != nil {
+		r.logger.Error("failed to reload certificates", slog.String("err", err.Error()))
+		return
+	}
+
+	r.logger.Info("certificates reloaded")
+}
+
+func (r *Reloader) changed() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, name := range r.files() {
+		info, err := os.Stat(name)
+		if err != nil {
+			continue
+		}
+		if !info.ModTime().Equal(r.modified[name]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Reloader) files() []string {
+	var files []string
+	for _, name := range []string{r.opts.CAFile, r.opts.CertFile, r.opts.KeyFile} {
+		if name != "" {
+			files = append(files, name)
+		}
+	}
+	return files
+}
+
+func (r *Reloader) load() error {
+	modified := make(map[string]time.Time)
+	for _, name := range r.files() {
+		info, err := os.Stat(name)
+		if err != nil {
+			return err
+		}
+		modified[name] = info.ModTime()
+	}
+
+	pool, err := loadPool(r.opts.CAFile)
+	if err != nil {
+		return err
+	}
+
+	var cert *tls.Certificate
+	if r.opts.CertFile != "" {
+		c, err := tls.LoadX509KeyPair(r.opts.CertFile, r.opts.KeyFile)
+		if err != nil {
+			return fmt.Errorf("load client certificate: %w", err)
+		}
+		cert = &c
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pool = pool
+	r.cert = cert
+	r.modified = modified
+
+	return nil
+}
+
+func loadPool(caFile string) (*x509.CertPool, error) {
+	if caFile == "" {
+		return x509.SystemCertPool()
+	}
+
+	data, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in %s", caFile)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,157 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log/slog"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type authority struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	file string
+}
+
+func newAuthority(t *testing.T) *authority {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return &authority{cert: cert, key: key, file: file}
+}
+
+// issue signs a server certificate for the given names and addresses.
+func (a *authority) issue(t *testing.T, names []string, ips []net.IP) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "server"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     names,
+		IPAddresses:  ips,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, a.cert, &key.PublicKey, a.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// handshake dials a TLS server presenting cert with the client config.
+func handshake(t *testing.T, cert tls.Certificate, client *tls.Config) error {
+	t.Helper()
+
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{cert}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_ = conn.(*tls.Conn).Handshake()
+	}()
+
+	conn, err := tls.Dial("tcp", ln.Addr().String(), client)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func TestReloaderVerifiesServerName(t *testing.T) {
+	ca := newAuthority(t)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		name       string
+		serverName string
+		cert       tls.Certificate
+		ok         bool
+	}{
+		{"ip host, matching ip", "127.0.0.1", ca.issue(t, nil, []net.IP{net.ParseIP("127.0.0.1")}), true},
+		{"ip host, other name", "127.0.0.1", ca.issue(t, []string{"other.example"}, nil), false},
+		{"ip host, other ip", "127.0.0.1", ca.issue(t, nil, []net.IP{net.ParseIP("10.0.0.1")}), false},
+		{"dns host, matching name", "upstream.example", ca.issue(t, []string{"upstream.example"}, nil), true},
+		{"dns host, other name", "upstream.example", ca.issue(t, []string{"other.example"}, nil), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewReloader(Options{Enabled: true, CAFile: ca.file, ServerName: tt.serverName}, logger)
+			if err != nil {
+				t.Fatalf("NewReloader failed: %v", err)
+			}
+
+			err = handshake(t, tt.cert, r.TLSConfig())
+			if tt.ok && err != nil {
+				t.Fatalf("handshake failed: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("handshake accepted a certificate for another server")
+			}
+		})
+	}
+}
+
+func TestReloaderRequiresServerName(t *testing.T) {
+	ca := newAuthority(t)
+
+	if _, err := NewReloader(Options{Enabled: true, CAFile: ca.file}, slog.New(slog.NewTextHandler(io.Discard, nil))); err == nil {
+		t.Fatal("NewReloader accepted an empty server name")
+	}
+}
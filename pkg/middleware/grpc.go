@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	RequestIdMetadataKey     = "x-request-id"
+	AuthorizationMetadataKey = "authorization"
+)
+
+// ACCESS_TOKEN holds the bearer token of an authenticated request.
+const ACCESS_TOKEN = "accessToken"
+
+func RequestIdUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(withRequestId(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+func RequestIdStreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(withRequestId(ctx), desc, cc, method, opts...)
+	}
+}
+
+func withRequestId(ctx context.Context) context.Context {
+	rid, ok := ctx.Value(REQUEST_ID).(string)
+	if !ok || rid == "" {
+		return ctx
+	}
+
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(RequestIdMetadataKey)) > 0 {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, RequestIdMetadataKey, rid)
+}
+
+// AuthorizationUnaryClientInterceptor forwards the access token of the
+// authenticated caller so upstreams can authorize the call themselves.
+func AuthorizationUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(withAuthorization(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+func AuthorizationStreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(withAuthorization(ctx), desc, cc, method, opts...)
+	}
+}
+
+func withAuthorization(ctx context.Context) context.Context {
+	token, ok := ctx.Value(ACCESS_TOKEN).(string)
+	if !ok || token == "" {
+		return ctx
+	}
+
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(AuthorizationMetadataKey)) > 0 {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, AuthorizationMetadataKey, "Bearer "+token)
+}
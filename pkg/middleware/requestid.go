@@ -1,17 +1,32 @@
 package middleware
 
 import (
+	"regexp"
+
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
 	"github.com/google/uuid"
 )
 
 const REQUEST_ID = "requestId"
 
+const RequestIdHeader = "X-Request-Id"
+
+var requestIdPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// AttachRequestId reuses a well-formed X-Request-Id sent by the client or a
+// load balancer and generates a new one otherwise.
 func AttachRequestId() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		requestId := uuid.NewString()
+		// the header is backed by a reused request buffer, while the id is
+		// kept by spans, logs and background jobs after the request
+		requestId := utils.CopyString(c.Get(RequestIdHeader))
+		if !requestIdPattern.MatchString(requestId) {
+			requestId = uuid.NewString()
+		}
+
 		c.Locals(REQUEST_ID, requestId)
-		c.Response().Header.Set("X-Request-Id", requestId)
+		c.Response().Header.Set(RequestIdHeader, requestId)
 		return c.Next()
 	}
 }